@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func addCommand(t *core.Track) *cobra.Command {
+	add := &cobra.Command{
+		Use:   "add PROJECT RANGE [NOTE...]",
+		Short: "Add a completed record for a project",
+		Long: fmt.Sprintf(`Add a completed record for a project
+
+The time range is given as a single argument, optionally preceded by a date.
+Without a date, the range refers to today. Examples:
+
+  "09:00 - 10:30"
+  "yesterday 14:00-15:00"
+  "last friday 10:00 - 1h30m"
+  "2022-12-31 %s23:00 - 1h30m"
+
+The record must not overlap with any existing record.
+Records can be added while another record is running.
+
+Everything after the time range is considered a note for the record.
+Notes can contain tags, denoted by the prefix "%s", like "%stag"`, util.PrevDayPrefix, core.TagPrefix, core.TagPrefix),
+		Aliases:           []string{"a"},
+		Args:              util.WrappedArgs(cobra.MinimumNArgs(2)),
+		ValidArgsFunction: completeProjectAndTags(t),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project := args[0]
+
+			if !t.ProjectExists(project) {
+				return fmt.Errorf("failed to add record: project '%s' does not exist", project)
+			}
+
+			proj, err := t.LoadProject(project)
+			if err != nil {
+				return fmt.Errorf("failed to add record: %s", err)
+			}
+			if err := t.CheckArchived(proj.Name); err != nil {
+				return fmt.Errorf("failed to add record: %s", err)
+			}
+
+			start, end, err := util.ParseDateTimeRange(args[1], t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to add record: %s", err)
+			}
+			if end.IsZero() {
+				return fmt.Errorf("failed to add record: missing end time")
+			}
+			if end.After(time.Now()) {
+				return fmt.Errorf("failed to add record: can't add a record ending in the future")
+			}
+
+			tags, err := core.ExtractTagsSlice(args[2:])
+			if err != nil {
+				return fmt.Errorf("failed to add record: %s", err)
+			}
+
+			record := core.Record{
+				Project: proj.Name,
+				Start:   start,
+				End:     end,
+				Note:    strings.Join(args[2:], " "),
+				Tags:    tags,
+				Pause:   []core.Pause{},
+			}
+
+			if err = t.AddRecord(&record, &proj); err != nil {
+				return fmt.Errorf("failed to add record: %s", err)
+			}
+
+			out.Success(
+				"Added record in '%s' from %s to %s",
+				proj.Name, record.Start.Format(util.DateTimeFormat), record.End.Format(util.DateTimeFormat),
+			)
+			return nil
+		},
+	}
+
+	return add
+}
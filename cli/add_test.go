@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdd(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	running := core.Record{Project: "test", Start: util.DateTime(2001, 2, 5, 9, 0, 0), Note: "Running"}
+	err = track.SaveRecord(&running, false)
+	if err != nil {
+		t.Fatal("error saving running record")
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "test", "2001-02-03 10:00 - 11:30", "Note", "+tag"})
+
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	record, err := track.LoadRecord(util.DateTime(2001, 2, 3, 10, 0, 0))
+	if err != nil {
+		t.Fatal("error loading record - record should exist")
+	}
+	assert.Equal(t, util.DateTime(2001, 2, 3, 11, 30, 0), record.End, "Wrong record end")
+	assert.Equal(t, "Note +tag", record.Note, "Wrong record note")
+	assert.Equal(t, map[string]string{"tag": ""}, record.Tags, "Wrong record tags")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "test", "2001-02-03 11:00 - 12:00"})
+
+	err = cmd.Execute()
+	assert.NotNil(t, err, "should fail with overlapping records error")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "test", "2001-02-04 <10:30 - 30m"})
+
+	err = cmd.Execute()
+	assert.NotNil(t, err, "should fail with overlapping records error")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "test", "2001-02-04 08:00 - 09:00"})
+
+	err = cmd.Execute()
+	assert.Nil(t, err, "should not fail for a record on the next day")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "test", "2001-02-03 12:00 - ?"})
+
+	err = cmd.Execute()
+	assert.NotNil(t, err, "should fail with missing end time error")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"add", "foo", "2001-02-03 12:00 - 13:00"})
+
+	err = cmd.Execute()
+	assert.NotNil(t, err, "should fail with missing project error")
+
+	open, err := track.OpenRecord()
+	if err != nil {
+		t.Fatal("error loading open record")
+	}
+	assert.NotNil(t, open, "record should still be running")
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func archiveCommand(t *core.Track) *cobra.Command {
+	archive := &cobra.Command{
+		Use:   "archive",
+		Short: "Archive a resource",
+		Long: `Archive a resource
+
+Archived resources are excluded from lists and reports.
+Use $ track unarchive to restore them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	archive.AddCommand(archiveProjectCommand(t))
+	archive.AddCommand(archiveWorkspaceCommand(t))
+
+	archive.Long += "\n\n" + formatCmdTree(archive)
+	return archive
+}
+
+func unarchiveCommand(t *core.Track) *cobra.Command {
+	unarchive := &cobra.Command{
+		Use:   "unarchive",
+		Short: "Un-archive a resource",
+		Long:  `Un-archive a resource`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	unarchive.AddCommand(unarchiveProjectCommand(t))
+	unarchive.AddCommand(unarchiveWorkspaceCommand(t))
+
+	unarchive.Long += "\n\n" + formatCmdTree(unarchive)
+	return unarchive
+}
+
+func archiveProjectCommand(t *core.Track) *cobra.Command {
+	var recursive bool
+
+	archiveProject := &cobra.Command{
+		Use:   "project PROJECT",
+		Short: "Archive a project",
+		Long: `Archive a project
+
+Archived projects are excluded from lists and reports, unless flag --archived is used.
+No records can be started for archived projects and their descendants.
+
+Projects with descendants that are not archived can only be archived with flag --recursive.`,
+		Aliases: []string{"p"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			changed, err := t.ArchiveProject(name, true, recursive)
+			if err != nil {
+				return fmt.Errorf("failed to archive project: %s", err)
+			}
+			if len(changed) == 0 {
+				out.Warn("Project '%s' is already archived\n", name)
+				return nil
+			}
+			out.Success("Archived %d project(s): %s", len(changed), strings.Join(changed, ", "))
+			return nil
+		},
+	}
+	archiveProject.Flags().BoolVarP(&recursive, "recursive", "r", false, "Archive all descendants of the project, too")
+
+	return archiveProject
+}
+
+func unarchiveProjectCommand(t *core.Track) *cobra.Command {
+	var recursive bool
+
+	unarchiveProject := &cobra.Command{
+		Use:   "project PROJECT",
+		Short: "Un-archive a project",
+		Long: `Un-archive a project
+
+Projects with an archived parent can't be un-archived.
+Un-archive the parent first, or use flag --recursive on the parent.`,
+		Aliases: []string{"p"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			changed, err := t.ArchiveProject(name, false, recursive)
+			if err != nil {
+				return fmt.Errorf("failed to un-archive project: %s", err)
+			}
+			if len(changed) == 0 {
+				out.Warn("Project '%s' is not archived\n", name)
+				return nil
+			}
+			out.Success("Un-archived %d project(s): %s", len(changed), strings.Join(changed, ", "))
+			return nil
+		},
+	}
+	unarchiveProject.Flags().BoolVarP(&recursive, "recursive", "r", false, "Un-archive all descendants of the project, too")
+
+	return unarchiveProject
+}
+
+func archiveWorkspaceCommand(t *core.Track) *cobra.Command {
+	archiveWorkspace := &cobra.Command{
+		Use:   "workspace WORKSPACE",
+		Short: "Archive a workspace",
+		Long: `Archive a workspace
+
+Archived workspaces are excluded from $ track list workspaces,
+and from combining workspaces in reports and lists.
+The current workspace can't be archived.`,
+		Aliases: []string{"w"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			changed, err := setWorkspaceArchived(t, name, true)
+			if err != nil {
+				return fmt.Errorf("failed to archive workspace: %s", err)
+			}
+			if !changed {
+				out.Warn("Workspace '%s' is already archived\n", name)
+				return nil
+			}
+			out.Success("Archived workspace '%s'", name)
+			return nil
+		},
+	}
+
+	return archiveWorkspace
+}
+
+func unarchiveWorkspaceCommand(t *core.Track) *cobra.Command {
+	unarchiveWorkspace := &cobra.Command{
+		Use:     "workspace WORKSPACE",
+		Short:   "Un-archive a workspace",
+		Long:    "Un-archive a workspace",
+		Aliases: []string{"w"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			changed, err := setWorkspaceArchived(t, name, false)
+			if err != nil {
+				return fmt.Errorf("failed to un-archive workspace: %s", err)
+			}
+			if !changed {
+				out.Warn("Workspace '%s' is not archived\n", name)
+				return nil
+			}
+			out.Success("Un-archived workspace '%s'", name)
+			return nil
+		},
+	}
+
+	return unarchiveWorkspace
+}
+
+// setWorkspaceArchived archives or un-archives a workspace. Returns whether anything was changed.
+func setWorkspaceArchived(t *core.Track, name string, archive bool) (bool, error) {
+	if !t.WorkspaceExists(name) {
+		return false, fmt.Errorf("workspace '%s' does not exist", name)
+	}
+	archived, err := t.IsWorkspaceArchived(name)
+	if err != nil {
+		return false, err
+	}
+	if archived == archive {
+		return false, nil
+	}
+	return true, t.ArchiveWorkspace(name, archive)
+}
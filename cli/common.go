@@ -2,10 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render"
+	"github.com/mlange-42/track/render/chart"
+	"github.com/mlange-42/track/render/table"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
 )
@@ -24,9 +28,10 @@ type filterOptions struct {
 	start           string
 	end             string
 	includeArchived bool
+	round           bool
 }
 
-func createFilters(options *filterOptions, projects map[string]core.Project, filterProjects bool) (core.FilterFunctions, error) {
+func createFilters(options *filterOptions, projects map[string]core.Project, filterProjects bool, cal util.Calendar) (core.FilterFunctions, error) {
 	filters := []core.FilterFunction{}
 
 	if filterProjects && len(options.projects) > 0 {
@@ -48,7 +53,7 @@ func createFilters(options *filterOptions, projects map[string]core.Project, fil
 		filters = append(filters, core.FilterByTagsAny(tags))
 	}
 
-	startTime, endTime, err := parseStartEnd(options)
+	startTime, endTime, err := parseStartEnd(options, cal)
 	if err != nil {
 		return core.FilterFunctions{}, err
 	}
@@ -58,26 +63,131 @@ func createFilters(options *filterOptions, projects map[string]core.Project, fil
 	return ff, nil
 }
 
-func parseStartEnd(options *filterOptions) (time.Time, time.Time, error) {
+// addWorkspaceFlags adds flags for combining workspaces to a command,
+// and selects the workspaces before the command is run
+func addWorkspaceFlags(t *core.Track, command *cobra.Command, persistent bool) {
+	var workspaces []string
+	var all bool
+
+	flags := command.Flags()
+	if persistent {
+		flags = command.PersistentFlags()
+	}
+	flags.StringSliceVar(&workspaces, "workspaces", []string{}, "Workspaces to combine (comma-separated). Projects are namespaced like <ws>/project")
+	flags.BoolVar(&all, "all-workspaces", false, "Combine all workspaces that are not archived. Projects are namespaced like <ws>/project")
+
+	selectWorkspaces := func(cmd *cobra.Command, args []string) error {
+		if all {
+			var err error
+			workspaces, err = t.ActiveWorkspaces()
+			if err != nil {
+				return fmt.Errorf("failed to select workspaces: %s", err)
+			}
+		}
+		if err := t.ReadWorkspaces(workspaces); err != nil {
+			return fmt.Errorf("failed to select workspaces: %s", err)
+		}
+		return nil
+	}
+	if persistent {
+		// Cobra runs only the closest persistent pre-run, so the root's one is called explicitly
+		command.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Root().PersistentPreRunE(cmd, args); err != nil {
+				return err
+			}
+			return selectWorkspaces(cmd, args)
+		}
+	} else {
+		command.PreRunE = selectWorkspaces
+	}
+}
+
+// createReporter creates a reporter, and applies rounding if requested by the options
+func createReporter(t *core.Track, options *filterOptions, filters core.FilterFunctions, start, end time.Time) (*core.Reporter, error) {
+	reporter, err := core.NewReporter(t, options.projects, filters, options.includeArchived, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if options.round {
+		if err := reporter.ApplyRounding(); err != nil {
+			return nil, err
+		}
+	}
+	return reporter, nil
+}
+
+func parseStartEnd(options *filterOptions, cal util.Calendar) (time.Time, time.Time, error) {
 	var err error
 	var startTime time.Time
 	var endTime time.Time
 	if len(options.start) > 0 {
-		startTime, err = util.ParseDate(options.start)
+		startTime, _, err = util.ParseDateRange(options.start, cal)
 		if err != nil {
 			return startTime, endTime, err
 		}
 	}
 	if len(options.end) > 0 {
-		endTime, err = util.ParseDate(options.end)
+		_, endTime, err = util.ParseDateRange(options.end, cal)
 		if err != nil {
 			return startTime, endTime, err
 		}
-		endTime = endTime.Add(time.Hour * 24)
 	}
 	return startTime, endTime, nil
 }
 
+// addFormatFlag adds a flag for the table format to a command
+func addFormatFlag(command *cobra.Command, format *string) {
+	command.Flags().StringVarP(format, "format", "f", table.Text, fmt.Sprintf("Output format (%s)", strings.Join(table.Formats, "|")))
+}
+
+// renderTable renders a table in the given format to the standard output
+func renderTable(tab *table.Table, format string) error {
+	renderer, err := table.NewRenderer(format, tab)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(out.StdOut)
+}
+
+// imageOptions are options for graphical output of reports
+type imageOptions struct {
+	svg   bool
+	png   bool
+	scale int
+}
+
+// addImageFlags adds flags for SVG and PNG output to a command
+func addImageFlags(command *cobra.Command, options *imageOptions) {
+	command.Flags().BoolVar(&options.svg, "svg", false, "Generate an SVG image")
+	command.Flags().BoolVar(&options.png, "png", false, "Generate a PNG image")
+	command.Flags().IntVar(&options.scale, "scale", 2, "Pixels per unit for PNG output")
+}
+
+// enabled reports whether graphical output is requested
+func (o *imageOptions) enabled() bool {
+	return o.svg || o.png
+}
+
+// check checks the image options for consistency
+func (o *imageOptions) check() error {
+	if o.svg && o.png {
+		return fmt.Errorf("flags --svg and --png are mutually exclusive")
+	}
+	if o.scale < 1 {
+		return fmt.Errorf("argument --scale must be > 0")
+	}
+	return nil
+}
+
+// renderImage renders a chart canvas as SVG or PNG to the standard output
+func renderImage(canvas *chart.Canvas, options *imageOptions) error {
+	var renderer render.Renderer = chart.SvgRenderer{Canvas: canvas}
+	if options.png {
+		renderer = chart.PngRenderer{Canvas: canvas, Scale: options.scale}
+	}
+	return renderer.Render(out.StdOut)
+}
+
 func confirm(question, yes string) bool {
 	answer, err := out.Scan(question)
 	if err != nil {
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mlange-42/track/core"
+	"github.com/spf13/cobra"
+)
+
+// completeProjectAndTags completes a project name as first argument,
+// and tags from the project's tag schema for further arguments
+func completeProjectAndTags(t *core.Track) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeProjects(t, toComplete), cobra.ShellCompDirectiveNoFileComp
+		}
+		if !strings.HasPrefix(toComplete, core.TagPrefix) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		schema, err := t.LoadTagSchema(args[0])
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return completeTags(schema, toComplete)
+	}
+}
+
+// completeProjects returns the names of all non-archived projects starting with prefix
+func completeProjects(t *core.Track, prefix string) []string {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for name, p := range projects {
+		if !p.Archived && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeTags completes a tag from a schema.
+// Completes tag names first, and allowed values after the "=".
+func completeTags(schema core.TagSchema, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tag := strings.TrimPrefix(toComplete, core.TagPrefix)
+	if !strings.Contains(tag, "=") {
+		result := []string{}
+		for name := range schema {
+			if strings.HasPrefix(name, tag) {
+				result = append(result, core.TagPrefix+name+"=")
+			}
+		}
+		sort.Strings(result)
+		return result, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	name, value := core.ParseTag(tag)
+	spec, ok := schema[name]
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	result := []string{}
+	for _, v := range spec.Values {
+		if strings.HasPrefix(v, value) {
+			result = append(result, core.TagPrefix+name+"="+v)
+		}
+	}
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteTags(t *testing.T) {
+	schema := core.TagSchema{
+		"activity": core.TagSpec{Values: []string{"coding", "meeting", "mentoring"}},
+		"ticket":   core.TagSpec{},
+	}
+
+	result, dir := completeTags(schema, "+")
+	assert.Equal(t, []string{"+activity=", "+ticket="}, result, "Wrong tag names")
+	assert.NotZero(t, dir&cobra.ShellCompDirectiveNoSpace, "Expected no space after tag name")
+
+	result, _ = completeTags(schema, "+ti")
+	assert.Equal(t, []string{"+ticket="}, result, "Wrong tag names")
+
+	result, _ = completeTags(schema, "+activity=me")
+	assert.Equal(t, []string{"+activity=meeting", "+activity=mentoring"}, result, "Wrong tag values")
+
+	result, _ = completeTags(schema, "+ticket=")
+	assert.Empty(t, result, "Expected no values for tag without allowed values")
+}
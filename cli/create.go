@@ -30,18 +30,39 @@ func createCommand(t *core.Track) *cobra.Command {
 func createProjectCommand(t *core.Track) *cobra.Command {
 	var parent string
 	var requiredTags []string
-	var color uint8
-	var fgColor uint8
+	var color string
+	var fgColor string
 	var symbol string
+	var template string
+	var params map[string]string
+	var description string
+	var client string
 
 	createProject := &cobra.Command{
-		Use:     "project PROJECT",
-		Short:   "Create a new project",
+		Use:   "project PROJECT",
+		Short: "Create a new project",
+		Long: `Create a new project
+
+With flag --template, a subtree of projects is created from a project template.
+Templates are YAML files in the 'templates' directory of the track root.
+Placeholder {name} in the template is replaced by the given PROJECT,
+further placeholders are replaced by the values given with flag --param.
+
+See: $ track list templates`,
 		Aliases: []string{"p"},
 		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
+			if template != "" {
+				for _, flag := range []string{"tags", "color", "fg-color", "symbol", "description", "client"} {
+					if cmd.Flags().Changed(flag) {
+						return fmt.Errorf("failed to create project: flag --%s can't be used with --template", flag)
+					}
+				}
+				return createFromTemplate(t, template, name, parent, params)
+			}
+
 			if !cmd.Flags().Changed("symbol") {
 				symbol = string([]rune(name)[0])
 			}
@@ -49,8 +70,20 @@ func createProjectCommand(t *core.Track) *cobra.Command {
 				return fmt.Errorf("failed to create project: --symbol must be a single character")
 			}
 
+			bgCol, err := util.ParseColor(color)
+			if err != nil {
+				return fmt.Errorf("failed to create project: %s", err)
+			}
+			fgCol, err := util.ParseColor(fgColor)
+			if err != nil {
+				return fmt.Errorf("failed to create project: %s", err)
+			}
+
 			requiredTags = util.Unique(requiredTags)
-			project := core.NewProject(name, parent, symbol, requiredTags, fgColor, color)
+			project := core.NewProject(name, parent, symbol, requiredTags, 0, 0)
+			project.SetColors(fgCol, bgCol)
+			project.Description = description
+			project.Client = client
 
 			if err := t.CheckParents(project); err != nil {
 				return fmt.Errorf("failed to create project: %s", err)
@@ -67,31 +100,78 @@ func createProjectCommand(t *core.Track) *cobra.Command {
 
 	createProject.Flags().StringVarP(&parent, "parent", "p", "", "Parent project of this project")
 	createProject.Flags().StringSliceVarP(&requiredTags, "tags", "t", []string{}, "Tags that are required for records in this project")
-	createProject.Flags().Uint8VarP(&color, "color", "c", 0, "Background color for the project, as color index 0..256.\nSee: $ track list colors")
-	createProject.Flags().Uint8VarP(&fgColor, "fg-color", "f", 15, "Foreground color for the project, as color index 0..256.\nSee: $ track list colors")
+	createProject.Flags().StringVarP(&color, "color", "c", "0", "Background color for the project, as color index 0..255 or hex color like #ff8800.\nSee: $ track list colors")
+	createProject.Flags().StringVarP(&fgColor, "fg-color", "f", "15", "Foreground color for the project, as color index 0..255 or hex color like #ff8800.\nSee: $ track list colors")
 	createProject.Flags().StringVarP(&symbol, "symbol", "s", "", "Symbol for the project. Defaults to the first letter of the name")
+	createProject.Flags().StringVarP(&description, "description", "d", "", "Description of the project")
+	createProject.Flags().StringVar(&client, "client", "", "Client or owner of the project")
+	createProject.Flags().StringVarP(&template, "template", "T", "", "Project template to create a subtree of projects from")
+	createProject.Flags().StringToStringVar(&params, "param", map[string]string{}, "Template parameters, like 'rate=90' (comma-separated)")
 
 	return createProject
 }
 
+func createFromTemplate(t *core.Track, template, name, parent string, params map[string]string) error {
+	values := map[string]string{core.TemplateNameParameter: name}
+	for k, v := range params {
+		values[k] = v
+	}
+	tmpl, err := t.LoadProjectTemplate(template, values)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %s", err)
+	}
+	projects, err := t.CreateFromTemplate(tmpl, parent)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %s", err)
+	}
+	for _, p := range projects {
+		out.Print("%s\n", p.Name)
+	}
+	out.Success("Created %d projects from template '%s'", len(projects), template)
+	return nil
+}
+
 func createWorkspaceCommand(t *core.Track) *cobra.Command {
+	var from string
+	var projectsOnly bool
+
 	createWorkspace := &cobra.Command{
-		Use:     "workspace WORKSPACE",
-		Short:   "Create a new workspace",
+		Use:   "workspace WORKSPACE",
+		Short: "Create a new workspace",
+		Long: `Create a new workspace
+
+With flag --from, the projects, records and config of another workspace are copied to the new workspace.
+With additional flag --projects-only, only the projects and config are copied.
+This can be used to use the project tree of a workspace as a template, e.g. for a new client.`,
 		Aliases: []string{"w"},
 		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			err := t.CreateWorkspace(name)
+			if from == "" {
+				if projectsOnly {
+					return fmt.Errorf("failed to create workspace: flag --projects-only requires flag --from")
+				}
+				err := t.CreateWorkspace(name)
+				if err != nil {
+					return fmt.Errorf("failed to create workspace: %s", err.Error())
+				}
+
+				out.Success("Created workspace '%s'", name)
+				return nil
+			}
+
+			projects, records, err := t.CloneWorkspace(name, from, projectsOnly)
 			if err != nil {
 				return fmt.Errorf("failed to create workspace: %s", err.Error())
 			}
-
-			out.Success("Created workspace '%s'", name)
+			out.Success("Created workspace '%s' from '%s' (%d projects, %d records)", name, from, projects, records)
 			return nil
 		},
 	}
 
+	createWorkspace.Flags().StringVar(&from, "from", "", "Workspace to copy projects and records from")
+	createWorkspace.Flags().BoolVar(&projectsOnly, "projects-only", false, "Copy only projects, no records. Requires flag --from")
+
 	return createWorkspace
 }
@@ -1,12 +1,12 @@
 package cli
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,14 +73,15 @@ func TestCreateProjectFlags(t *testing.T) {
 	assert.True(t, track.ProjectExists("test"), "Project should exist")
 
 	ref := core.NewProject("child", "test", "C", []string{"tag1", "tag2"}, 15, 0)
+	ref.SetColors(util.PaletteColor(15), util.TrueColor(0xff, 0x88, 0x00))
 
 	cmd = RootCommand(track, "")
 	cmd.SetArgs([]string{
 		"create", "project", ref.Name,
 		"--parent", ref.Parent,
 		"--symbol", ref.Symbol,
-		"--color", fmt.Sprintf("%d", ref.Color),
-		"--fg-color", fmt.Sprintf("%d", ref.FgColor),
+		"--color", ref.Color.String(),
+		"--fg-color", ref.FgColor.String(),
 		"--tags", strings.Join(ref.RequiredTags, ","),
 	})
 
@@ -12,26 +12,32 @@ import (
 
 func deleteCommand(t *core.Track) *cobra.Command {
 	var dryRun bool
+	var reason string
 
 	delete := &cobra.Command{
-		Use:     "delete",
-		Short:   "Delete a resource",
-		Long:    `Delete a resource`,
+		Use:   "delete",
+		Short: "Delete a resource",
+		Long: `Delete a resource
+
+Deleted resources are moved to the trash of the workspace.
+They can be restored using $ track restore`,
 		Aliases: []string{"D"},
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = cmd.Help()
 		},
 	}
 	delete.PersistentFlags().BoolVar(&dryRun, "dry", false, "Dry run: do not actually change any files")
+	delete.PersistentFlags().StringVarP(&reason, "reason", "r", "deleted by user", "Reason for the deletion, shown in the trash")
 
-	delete.AddCommand(deleteRecordCommand(t, &dryRun))
-	delete.AddCommand(deleteProjectCommand(t, &dryRun))
+	delete.AddCommand(deleteRecordCommand(t, &reason, &dryRun))
+	delete.AddCommand(deleteProjectCommand(t, &reason, &dryRun))
+	delete.AddCommand(deleteWorkspaceCommand(t, &dryRun))
 
 	delete.Long += "\n\n" + formatCmdTree(delete)
 	return delete
 }
 
-func deleteRecordCommand(t *core.Track, dryRun *bool) *cobra.Command {
+func deleteRecordCommand(t *core.Track, reason *string, dryRun *bool) *cobra.Command {
 	var force bool
 
 	delete := &cobra.Command{
@@ -66,7 +72,7 @@ func deleteRecordCommand(t *core.Track, dryRun *bool) *cobra.Command {
 			if *dryRun {
 				out.Success("Deleted record %s from '%s' - dry-run", record.Start.Format(util.DateTimeFormat), record.Project)
 			} else {
-				err = t.DeleteRecord(&record)
+				err = t.DeleteRecord(&record, *reason)
 				if err != nil {
 					return fmt.Errorf("failed to delete record: %s", err)
 				}
@@ -81,7 +87,7 @@ func deleteRecordCommand(t *core.Track, dryRun *bool) *cobra.Command {
 	return delete
 }
 
-func deleteProjectCommand(t *core.Track, dryRun *bool) *cobra.Command {
+func deleteProjectCommand(t *core.Track, reason *string, dryRun *bool) *cobra.Command {
 	var force bool
 
 	delete := &cobra.Command{
@@ -120,7 +126,7 @@ func deleteProjectCommand(t *core.Track, dryRun *bool) *cobra.Command {
 				return fmt.Errorf("failed to delete project: aborted by user")
 			}
 
-			cnt, err := t.DeleteProject(&pNode.Value, true, *dryRun)
+			cnt, err := t.DeleteProject(&pNode.Value, true, *reason, *dryRun)
 			if err != nil {
 				return fmt.Errorf("failed to delete project: %s (deleted %d records)", err, cnt)
 			}
@@ -137,3 +143,50 @@ func deleteProjectCommand(t *core.Track, dryRun *bool) *cobra.Command {
 
 	return delete
 }
+
+func deleteWorkspaceCommand(t *core.Track, dryRun *bool) *cobra.Command {
+	var force bool
+
+	delete := &cobra.Command{
+		Use:   "workspace WORKSPACE",
+		Short: "Delete a workspace and all its projects and records",
+		Long: `Delete a workspace and all its projects and records
+
+Other than projects and records, workspaces are deleted permanently, including their trash.
+The current workspace can't be deleted.`,
+		Aliases: []string{"w"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			projects, records, err := t.DeleteWorkspace(name, true)
+			if err != nil {
+				return fmt.Errorf("failed to delete workspace: %s", err)
+			}
+
+			if !force && !confirm(
+				fmt.Sprintf(
+					"Really delete workspace '%s' with %d projects and %d records permanently? (yes!/n): ",
+					name, projects, records,
+				),
+				"yes!",
+			) {
+				return fmt.Errorf("failed to delete workspace: aborted by user")
+			}
+
+			if *dryRun {
+				out.Success("Deleted workspace '%s' (%d projects, %d records) - dry-run", name, projects, records)
+				return nil
+			}
+			if _, _, err = t.DeleteWorkspace(name, false); err != nil {
+				return fmt.Errorf("failed to delete workspace: %s", err)
+			}
+			out.Success("Deleted workspace '%s' (%d projects, %d records)", name, projects, records)
+			return nil
+		},
+	}
+
+	delete.Flags().BoolVarP(&force, "force", "F", false, "Don't prompt for confirmation.")
+
+	return delete
+}
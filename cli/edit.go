@@ -86,7 +86,7 @@ Uses the current date if only a time is given.`,
 				}
 				tm = util.DateAndTime(time.Now(), tm)
 			case 2:
-				date, err := util.ParseDate(args[0])
+				date, err := util.ParseDate(args[0], t.Config.Calendar())
 				if err != nil {
 					return fmt.Errorf("failed to edit record: %s", err)
 				}
@@ -194,6 +194,7 @@ See file .track/config.yml to configure the editor to be used.`,
 }
 
 func editConfigCommand(t *core.Track, dryRun *bool) *cobra.Command {
+	var workspace bool
 
 	editConfig := &cobra.Command{
 		Use:   "config",
@@ -201,11 +202,21 @@ func editConfigCommand(t *core.Track, dryRun *bool) *cobra.Command {
 		Long: `Edit track's config
 
 Opens the config as a temporary YAML file for editing.
-See file .track/config.yml to configure the editor to be used.`,
+See file .track/config.yml to configure the editor to be used.
+
+With flag --workspace, edits the config overrides of the current workspace.
+See also: $ track list config`,
 		Aliases: []string{"c"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := editConfig(t, *dryRun)
+			path := t.ConfigPath()
+			var err error
+			if workspace {
+				path = t.WorkspaceConfigPath(t.Workspace())
+				err = editWorkspaceConfig(t, *dryRun)
+			} else {
+				err = editConfig(t, *dryRun)
+			}
 			if err != nil {
 				if err == ErrUserAbort {
 					return fmt.Errorf("failed to edit config: %s", err)
@@ -214,14 +225,16 @@ See file .track/config.yml to configure the editor to be used.`,
 			}
 
 			if *dryRun {
-				out.Success("Saved config to %s - dry-run", t.ConfigPath())
+				out.Success("Saved config to %s - dry-run", path)
 			} else {
-				out.Success("Saved config to %s", t.ConfigPath())
+				out.Success("Saved config to %s", path)
 			}
 			return nil
 		},
 	}
 
+	editConfig.Flags().BoolVarP(&workspace, "workspace", "w", false, "Edit the config overrides of the current workspace")
+
 	return editConfig
 }
 
@@ -240,7 +253,7 @@ See file .track/config.yml to configure the editor to be used.`,
 			var err error
 			date := util.ToDate(time.Now())
 			if len(args) > 0 {
-				date, err = util.ParseDate(args[0])
+				date, err = util.ParseDate(args[0], t.Config.Calendar())
 				if err != nil {
 					return fmt.Errorf("failed to edit day: %s", err)
 				}
@@ -286,7 +299,11 @@ func editRecord(t *core.Track, tm time.Time, dryRun bool) error {
 			if err != nil {
 				return err
 			}
-			if err = newRecord.Check(&project); err != nil {
+			schema, err := t.LoadTagSchema(project.Name)
+			if err != nil {
+				return err
+			}
+			if err = newRecord.Check(&project, schema); err != nil {
 				return err
 			}
 
@@ -332,6 +349,10 @@ func editDay(t *core.Track, date time.Time, dryRun bool) error {
 	if err != nil {
 		return err
 	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return err
+	}
 
 	return edit(t, records,
 		fmt.Sprintf("%[1]s Records for %s\n%[1]s Clear file to abort\n\n", core.CommentPrefix, date.Format(util.DateFormat)),
@@ -413,7 +434,7 @@ func editDay(t *core.Track, date time.Time, dryRun bool) error {
 					if !ok {
 						return fmt.Errorf("project '%s' does not exist (%s)", rec.Project, rec.Start.Format(util.TimeFormat))
 					}
-					if err := rec.Check(&project); err != nil {
+					if err := rec.Check(&project, t.TagSchemaFor(project.Name, tree)); err != nil {
 						return err
 					}
 
@@ -459,12 +480,18 @@ func editDay(t *core.Track, date time.Time, dryRun bool) error {
 			}
 
 			if !dryRun {
+				kept := map[time.Time]bool{}
+				for _, rec := range newRecords {
+					kept[rec.Start] = true
+				}
 				for _, rec := range records {
-					t.DeleteRecord(&rec)
+					if !kept[rec.Start] {
+						t.DeleteRecord(&rec, "removed in day edit")
+					}
 				}
 
 				for _, rec := range newRecords {
-					t.SaveRecord(&rec, false)
+					t.SaveRecord(&rec, true)
 				}
 			}
 
@@ -531,6 +558,38 @@ func editConfig(t *core.Track, dryRun bool) error {
 		})
 }
 
+func editWorkspaceConfig(t *core.Track, dryRun bool) error {
+	path := t.WorkspaceConfigPath(t.Workspace())
+	conf, err := core.LoadWorkspaceConfig(path)
+	if err != nil {
+		return err
+	}
+
+	return edit(t, &conf,
+		fmt.Sprintf("%[1]s Track workspace config for '%s'\n%[1]s Entries override the global config\n\n", core.YamlCommentPrefix, t.Workspace()),
+		core.YamlCommentPrefix,
+		func(r *core.WorkspaceConfig) ([]byte, error) {
+			return yaml.Marshal(r)
+		},
+		func(b []byte) error {
+			var newConfig core.WorkspaceConfig
+			if err := yaml.Unmarshal(b, &newConfig); err != nil {
+				return err
+			}
+			effective := newConfig.Apply(t.GlobalConfig)
+			if err := effective.Check(); err != nil {
+				return err
+			}
+
+			if !dryRun {
+				if err = newConfig.Save(path); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
 func edit[T any](t *core.Track, obj T, comment string, commentPrefix string, marshal func(T) ([]byte, error), unmarshal func(b []byte) error) error {
 	content, err := marshal(obj)
 	if err != nil {
@@ -645,9 +704,11 @@ func renameProject(t *core.Track, p *core.Project, name string, dryRun bool) (in
 		recordCount++
 	}
 
-	_, err = t.DeleteProject(p, false, dryRun)
-	if err != nil {
-		return recordCount, projectCount, err
+	// The old project file is removed directly, as a renamed project is not a deletion for the trash
+	if !dryRun {
+		if err := os.Remove(t.ProjectPath(p.Name)); err != nil {
+			return recordCount, projectCount, err
+		}
 	}
 
 	p.Name = name
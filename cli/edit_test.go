@@ -91,6 +91,10 @@ func TestEditProjectRenameArchive(t *testing.T) {
 	assert.False(t, track.ProjectExists("test"), "Project should not exist")
 	assert.True(t, track.ProjectExists("other"), "Project should exist")
 
+	trash, _, err := track.LoadTrash()
+	assert.Nil(t, err)
+	assert.Empty(t, trash, "Renaming should not create trash entries")
+
 	newRec, err := track.LoadRecord(record.Start)
 	if err != nil {
 		t.Fatal("error loading record")
@@ -166,4 +170,8 @@ func TestEditRecordDay(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error executing command: %s", err.Error())
 	}
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"edit", "day", "2001"})
+	assert.NotNil(t, cmd.Execute(), "Expected error for a year instead of a day")
 }
@@ -39,7 +39,10 @@ func exportRecordsCommand(t *core.Track) *cobra.Command {
 		Long: `Export records
 
 Records can be exported in CSV, JSON and YAML format.
-The default export format is CSV.`,
+The default export format is CSV.
+
+With flag --round, the rounding policies of the config and projects are applied to the exported records.
+Rounding only changes the end times of exported records, the stored records are not modified.`,
 		Aliases: []string{"r"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -48,13 +51,27 @@ The default export format is CSV.`,
 				return fmt.Errorf("failed to export records: %s", err)
 			}
 
-			filters, err := createFilters(&options, projects, true)
+			filters, err := createFilters(&options, projects, true, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to export records: %s", err)
 			}
 
-			fn, results, _ := t.AllRecordsFiltered(filters, false)
-			go fn()
+			var results chan core.FilterResult
+			if options.round {
+				recs, err := t.LoadAllRecordsFiltered(filters)
+				if err != nil {
+					return fmt.Errorf("failed to export records: %s", err)
+				}
+				recs, err = t.RoundRecords(recs)
+				if err != nil {
+					return fmt.Errorf("failed to export records: %s", err)
+				}
+				results = recordsToResults(recs)
+			} else {
+				var fn func()
+				fn, results, _ = t.AllRecordsFiltered(filters, false)
+				go fn()
+			}
 
 			io := out.StdOut
 			var writer render.Renderer
@@ -80,7 +97,20 @@ The default export format is CSV.`,
 	records.Flags().BoolVar(&json, "json", false, "Export in JSON format")
 	records.Flags().BoolVar(&yaml, "yaml", false, "Export in YAML format")
 
+	records.Flags().BoolVar(&options.round, "round", false, "Apply the rounding policies of the config and projects to the exported records")
+
 	records.MarkFlagsMutuallyExclusive("json", "yaml")
 
+	addWorkspaceFlags(t, records, false)
+
 	return records
 }
+
+func recordsToResults(records []core.Record) chan core.FilterResult {
+	results := make(chan core.FilterResult, len(records))
+	for _, rec := range records {
+		results <- core.FilterResult{Record: rec, Err: nil}
+	}
+	close(results)
+	return results
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
@@ -81,3 +82,55 @@ func TestExport(t *testing.T) {
 		t.Fatal("error executing command")
 	}
 }
+
+func TestExportRounded(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	project.Rounding = &core.Rounding{Mode: core.RoundUp, Increment: 15 * time.Minute}
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	record := core.Record{
+		Project: "test",
+		Start:   util.DateTime(2001, 2, 3, 4, 5, 0),
+		End:     util.DateTime(2001, 2, 3, 4, 12, 0),
+	}
+	err = track.SaveRecord(&record, false)
+	if err != nil {
+		t.Fatal("error saving record")
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"export", "records", "--round"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatal("error executing command")
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+
+	got := string(outStr)
+	expected := `start,end,project,total,work,pause,note,tags
+2001-02-03 04:05,2001-02-03 04:20,test,00:15,00:15,00:00,"",
+`
+	assert.Equal(t, expected, got, "unexpected CSV output")
+
+	stored, err := track.LoadRecord(record.Start)
+	if err != nil {
+		t.Fatal("error loading record")
+	}
+	assert.Equal(t, record.End, stored.End, "stored record should not be changed")
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 	"unicode/utf8"
 
 	"github.com/gookit/color"
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/table"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
@@ -31,6 +33,9 @@ func listCommand(t *core.Track) *cobra.Command {
 	list.AddCommand(listRecordsCommand(t))
 	list.AddCommand(listColorsCommand(t))
 	list.AddCommand(listTagsCommand(t))
+	list.AddCommand(listConfigCommand(t))
+	list.AddCommand(listTrashCommand(t))
+	list.AddCommand(listTemplatesCommand(t))
 
 	list.Long += "\n\n" + formatCmdTree(list)
 	return list
@@ -82,7 +87,7 @@ func listProjectsCommand(t *core.Track) *cobra.Command {
 					}
 					var str string
 					if t.Value.Name == active {
-						str = color.BgBlue.Sprintf("%s", name)
+						str = out.Highlight(name)
 					} else {
 						str = name
 					}
@@ -91,6 +96,9 @@ func listProjectsCommand(t *core.Track) *cobra.Command {
 					}
 					str += " "
 					str += t.Value.Render.Sprintf(" %s ", t.Value.Symbol)
+					if t.Value.Archived {
+						str += " (archived)"
+					}
 					return str
 				},
 				2,
@@ -99,28 +107,48 @@ func listProjectsCommand(t *core.Track) *cobra.Command {
 			return nil
 		},
 	}
-	listProjects.Flags().BoolVarP(&includeArchived, "archived", "a", false, "Include records from archived projects")
+	listProjects.Flags().BoolVarP(&includeArchived, "archived", "a", false, "Include archived projects, marked as archived")
+
+	addWorkspaceFlags(t, listProjects, false)
 
 	return listProjects
 }
 
 func listWorkspacesCommand(t *core.Track) *cobra.Command {
+	var includeArchived bool
+
 	listWorkspaces := &cobra.Command{
 		Use:     "workspaces",
 		Short:   "List all workspaces",
 		Aliases: []string{"w"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ws, err := t.AllWorkspaces()
+			var ws []string
+			var err error
+			if includeArchived {
+				ws, err = t.AllWorkspaces()
+			} else {
+				ws, err = t.ActiveWorkspaces()
+			}
 			if err != nil {
 				return fmt.Errorf("failed to load workspaces: %s", err)
 			}
 
 			for i, w := range ws {
+				label := w
+				if includeArchived {
+					archived, err := t.IsWorkspaceArchived(w)
+					if err != nil {
+						return fmt.Errorf("failed to load workspaces: %s", err)
+					}
+					if archived {
+						label += " (archived)"
+					}
+				}
 				if w == t.Workspace() {
-					out.Print("%s", color.BgBlue.Sprintf("%s", w))
+					out.Print("%s", out.Highlight(label))
 				} else {
-					out.Print("%s", w)
+					out.Print("%s", label)
 				}
 				if i < len(ws)-1 {
 					out.Print("\n")
@@ -129,12 +157,18 @@ func listWorkspacesCommand(t *core.Track) *cobra.Command {
 			return nil
 		},
 	}
+	listWorkspaces.Flags().BoolVarP(&includeArchived, "archived", "a", false, "Include archived workspaces")
 
 	return listWorkspaces
 }
 
 func listRecordsCommand(t *core.Track) *cobra.Command {
 	var includeArchived bool
+	var columnNames []string
+	var sortKeys []string
+	var limit int
+	var templateText string
+	var format string
 
 	listProjects := &cobra.Command{
 		Use:   "records [DATE]",
@@ -142,24 +176,80 @@ func listRecordsCommand(t *core.Track) *cobra.Command {
 		Long: `List all records for a date
 
 The date can either be a date in default formatting, like "2022-12-31",
-or a word like "yesterday" or  "today" (the default).`,
+or a word like "yesterday" or  "today" (the default).
+
+The date can also be a range, like "this week", "last month", "2022-W14", "2022-03" or "q1".
+In this case, all records in the range are listed.
+
+Use --columns to select the columns to show, from date, start, end, duration, pause, project, tags and note.
+Use --sort to sort by one or more columns, like "duration:desc" or "project,start".
+Use --format for a table with a header row, in csv, markdown, asciidoc or latex format.
+
+Use --template for custom output, with a Go text/template executed for each record:
+
+  track list records --template '{{.Project}};{{date .Start}};{{work .}};{{tags .}}'
+
+Available functions are date, time and duration to format fields,
+as well as work, pause and tags that take the record as argument.`,
 		Aliases:    []string{"r"},
 		Args:       util.WrappedArgs(cobra.MaximumNArgs(1)),
 		ArgAliases: []string{"date"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			date := util.ToDate(time.Now())
+			if templateText != "" && cmd.Flags().Changed("columns") {
+				return fmt.Errorf("failed to load records: flags --columns and --template are mutually exclusive")
+			}
+			if templateText != "" && format != table.Text {
+				return fmt.Errorf("failed to load records: flags --format and --template are mutually exclusive")
+			}
+			if err := table.CheckFormat(format); err != nil {
+				return fmt.Errorf("failed to load records: %s", err)
+			}
+			if limit < 0 {
+				return fmt.Errorf("failed to load records: limit must not be negative")
+			}
+			var columns []recordColumn
 			var err error
+			if cmd.Flags().Changed("columns") || format != table.Text {
+				columns, err = parseRecordColumns(columnNames)
+				if err != nil {
+					return fmt.Errorf("failed to load records: %s", err)
+				}
+			}
+			keys, err := parseRecordSort(sortKeys)
+			if err != nil {
+				return fmt.Errorf("failed to load records: %s", err)
+			}
+			var tmpl *template.Template
+			if templateText != "" {
+				tmpl, err = parseRecordTemplate(templateText)
+				if err != nil {
+					return fmt.Errorf("failed to load records: %s", err)
+				}
+			}
+
+			date := util.ToDate(time.Now())
+			dateEnd := util.AddDays(date, 1)
 			if len(args) > 0 {
-				date, err = util.ParseDate(args[0])
+				date, dateEnd, err = util.ParseDateRange(args[0], t.Config.Calendar())
 				if err != nil {
 					return fmt.Errorf("failed to load records: %s", err)
 				}
 			}
 
-			records, err := t.LoadDateRecordsExact(date)
+			var records []core.Record
+			label := date.Format(util.DateFormat)
+			if dateEnd.Equal(util.AddDays(date, 1)) {
+				records, err = t.LoadDateRecordsExact(date)
+			} else {
+				label = fmt.Sprintf("%s - %s", label, util.AddDays(dateEnd, -1).Format(util.DateFormat))
+				records, err = t.LoadAllRecordsFiltered(core.NewFilter([]core.FilterFunction{}, date, dateEnd))
+				if err == nil && len(records) == 0 {
+					err = core.ErrNoRecords
+				}
+			}
 			if err != nil {
 				if err == core.ErrNoRecords {
-					out.Warn("no records for %s", date.Format(util.DateFormat))
+					out.Warn("no records for %s", label)
 					return nil
 				}
 				return fmt.Errorf("failed to load records: %s", err)
@@ -169,24 +259,66 @@ or a word like "yesterday" or  "today" (the default).`,
 			if err != nil {
 				return fmt.Errorf("failed to export records: %s", err)
 			}
-			for _, record := range records {
-				project := projects[record.Project]
-				if includeArchived || !project.Archived {
-					printRecord(record, project)
+			if !includeArchived {
+				filtered := records[:0]
+				for _, record := range records {
+					if !projects[record.Project].Archived {
+						filtered = append(filtered, record)
+					}
+				}
+				records = filtered
+			}
+			sortRecords(records, keys)
+			if limit > 0 && len(records) > limit {
+				records = records[:limit]
+			}
+
+			if tmpl != nil {
+				for i := range records {
+					if err := tmpl.Execute(out.StdOut, &records[i]); err != nil {
+						return fmt.Errorf("failed to format record: %s", err)
+					}
+				}
+				return nil
+			}
+			if columns != nil {
+				tab := recordsTable(records, columns)
+				if format == table.Text {
+					tab.Header = nil
 				}
+				if err := renderTable(tab, format); err != nil {
+					return fmt.Errorf("failed to format records: %s", err)
+				}
+				return nil
+			}
+
+			truncate := out.IsTerminal()
+			for _, record := range records {
+				printRecord(record, projects[record.Project], truncate)
 			}
 			return nil
 		},
 	}
 	listProjects.Flags().BoolVarP(&includeArchived, "archived", "a", false, "Include records from archived projects")
+	listProjects.Flags().StringSliceVarP(&columnNames, "columns", "c", []string{"date", "start", "end", "duration", "pause", "project", "tags", "note"}, "Columns to show (comma-separated)")
+	listProjects.Flags().StringSliceVar(&sortKeys, "sort", []string{}, "Columns to sort by (comma-separated), with optional order, like 'duration:desc'")
+	listProjects.Flags().IntVarP(&limit, "limit", "n", 0, "Maximum number of records to list. No limit if zero")
+	listProjects.Flags().StringVar(&templateText, "template", "", "Go text/template for formatting each record")
+	addFormatFlag(listProjects, &format)
+
+	addWorkspaceFlags(t, listProjects, false)
 
 	return listProjects
 }
 
 func listColorsCommand(t *core.Track) *cobra.Command {
 	listColors := &cobra.Command{
-		Use:     "colors",
-		Short:   "Lists the 256 available colors",
+		Use:   "colors",
+		Short: "Lists the 256 available colors",
+		Long: `Lists the 256 available colors
+
+Project colors can also be given as hex colors like #ff8800.
+On terminals without true color support, they fall back to the closest available color.`,
 		Aliases: []string{"c"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -216,10 +348,118 @@ func listTagsCommand(t *core.Track) *cobra.Command {
 	}
 	listTags.Flags().BoolVarP(&includeArchived, "archived", "a", false, "Include records from archived projects")
 
+	addWorkspaceFlags(t, listTags, false)
+
 	return listTags
 }
 
-func printRecord(r core.Record, project core.Project) {
+func listTemplatesCommand(t *core.Track) *cobra.Command {
+	listTemplates := &cobra.Command{
+		Use:   "templates",
+		Short: "Lists all project templates",
+		Long: `Lists all project templates
+
+Templates are YAML files in the 'templates' directory of the track root.
+
+See also: $ track create project --template`,
+		Aliases: []string{"tp"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templates, err := t.AllTemplates()
+			if err != nil {
+				return fmt.Errorf("failed to list templates: %s", err.Error())
+			}
+			if len(templates) == 0 {
+				out.Warn("no templates in %s\n", t.TemplatesDir())
+				return nil
+			}
+			for _, name := range templates {
+				out.Print("%s\n", name)
+			}
+			return nil
+		},
+	}
+
+	return listTemplates
+}
+
+func listConfigCommand(t *core.Track) *cobra.Command {
+	listConfig := &cobra.Command{
+		Use:   "config",
+		Short: "Lists the effective config",
+		Long: `Lists the effective config
+
+Shows all config entries and where their values come from:
+the global config, or the config of the current workspace.
+
+See also: $ track edit config`,
+		Aliases: []string{"cf"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := t.EffectiveConfig()
+			if err != nil {
+				return fmt.Errorf("failed to list config: %s", err.Error())
+			}
+
+			keyLen := 0
+			valueLen := 0
+			for _, e := range entries {
+				if l := utf8.RuneCountInString(e.Key); l > keyLen {
+					keyLen = l
+				}
+				if l := utf8.RuneCountInString(e.Value); l > valueLen {
+					valueLen = l
+				}
+			}
+			for _, e := range entries {
+				out.Print("%-*s  %-*s  (%s)\n", keyLen, e.Key, valueLen, e.Value, e.Source)
+			}
+			return nil
+		},
+	}
+
+	return listConfig
+}
+
+func listTrashCommand(t *core.Track) *cobra.Command {
+	listTrash := &cobra.Command{
+		Use:   "trash",
+		Short: "Lists deleted records and projects in the trash",
+		Long: `Lists deleted records and projects in the trash
+
+See also: $ track restore, $ track empty-trash`,
+		Aliases: []string{"tr"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, skipped, err := t.LoadTrash()
+			if err != nil {
+				return fmt.Errorf("failed to list trash: %s", err.Error())
+			}
+			warnSkippedTrash(skipped)
+
+			nameLen := 0
+			for _, e := range entries {
+				if l := utf8.RuneCountInString(e.Name); l > nameLen {
+					nameLen = l
+				}
+			}
+			for _, e := range entries {
+				out.Print(
+					"%s  %s  %-7s  %-*s  %4d file(s)  %s\n",
+					e.ID, e.Deleted.Format(util.DateTimeFormat), e.Kind,
+					nameLen, e.Name, len(e.Files), e.Reason,
+				)
+			}
+			return nil
+		},
+	}
+
+	return listTrash
+}
+
+// printRecord prints a record in the default layout.
+// If truncate is true, project names and multi-line notes are shortened.
+func printRecord(r core.Record, project core.Project, truncate bool) {
 	date := r.Start.Format(util.DateFormat)
 	start := r.Start.Format(util.TimeFormat)
 
@@ -234,7 +474,7 @@ func printRecord(r core.Record, project core.Project) {
 
 	fillLen := 16 - utf8.RuneCountInString(r.Project)
 	name := r.Project
-	if fillLen < 0 {
+	if fillLen < 0 && truncate {
 		nameRunes := []rune(name)
 		name = string(nameRunes[:len(nameRunes)+fillLen-1]) + "."
 	}
@@ -243,13 +483,10 @@ func printRecord(r core.Record, project core.Project) {
 	if fillLen > 0 {
 		fill = strings.Repeat(" ", fillLen)
 	}
-	note := ""
-	if r.Note != "" {
-		parts := strings.SplitN(strings.ReplaceAll(r.Note, "\r\n", "\n"), "\n", 2)
-		note = parts[0]
-		if len(parts) > 1 {
-			note += " ..."
-		}
+	lines := noteLines(r.Note)
+	note := strings.Join(lines, " ")
+	if truncate && len(lines) > 1 {
+		note = lines[0] + " ..."
 	}
 	out.Print(
 		"%s%s %s %s %s - %s (%5s + %5s)  %s\n", name, fill,
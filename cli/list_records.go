@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/render/table"
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+// recordColumn is a column of the records list
+type recordColumn struct {
+	// Name of the column, used as header
+	Name string
+	// Format formats the column value of a record
+	Format func(r *core.Record) string
+	// Less compares the column values of two records
+	Less func(a, b *core.Record) bool
+	// AlignRight aligns the column to the right
+	AlignRight bool
+}
+
+var recordColumns = map[string]recordColumn{
+	"date": {
+		Format: func(r *core.Record) string { return r.Start.Format(util.DateFormat) },
+		Less:   func(a, b *core.Record) bool { return util.ToDate(a.Start).Before(util.ToDate(b.Start)) },
+	},
+	"start": {
+		Format: func(r *core.Record) string { return r.Start.Format(util.TimeFormat) },
+		Less:   func(a, b *core.Record) bool { return a.Start.Before(b.Start) },
+	},
+	"end": {
+		Format: func(r *core.Record) string {
+			if !r.HasEnded() {
+				return util.NoTimeString
+			}
+			return r.End.Format(util.TimeFormat)
+		},
+		Less: func(a, b *core.Record) bool {
+			if !a.HasEnded() || !b.HasEnded() {
+				return a.HasEnded() && !b.HasEnded()
+			}
+			return a.End.Before(b.End)
+		},
+	},
+	"duration": {
+		Format: func(r *core.Record) string {
+			return util.FormatDuration(r.Duration(util.NoTime, util.NoTime), false)
+		},
+		Less: func(a, b *core.Record) bool {
+			return a.Duration(util.NoTime, util.NoTime) < b.Duration(util.NoTime, util.NoTime)
+		},
+		AlignRight: true,
+	},
+	"pause": {
+		Format: func(r *core.Record) string {
+			return util.FormatDuration(r.PauseDuration(util.NoTime, util.NoTime), false)
+		},
+		Less: func(a, b *core.Record) bool {
+			return a.PauseDuration(util.NoTime, util.NoTime) < b.PauseDuration(util.NoTime, util.NoTime)
+		},
+		AlignRight: true,
+	},
+	"project": {
+		Format: func(r *core.Record) string { return r.Project },
+		Less:   func(a, b *core.Record) bool { return a.Project < b.Project },
+	},
+	"tags": {
+		Format: formatRecordTags,
+		Less:   func(a, b *core.Record) bool { return formatRecordTags(a) < formatRecordTags(b) },
+	},
+	"note": {
+		Format: func(r *core.Record) string { return strings.Join(noteLines(r.Note), " ") },
+		Less:   func(a, b *core.Record) bool { return a.Note < b.Note },
+	},
+}
+
+// recordSortKey is a column to sort records by
+type recordSortKey struct {
+	Column     recordColumn
+	Descending bool
+}
+
+// parseRecordColumns parses and checks column names
+func parseRecordColumns(names []string) ([]recordColumn, error) {
+	columns := make([]recordColumn, len(names))
+	for i, name := range names {
+		col, ok := recordColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column '%s'. Available columns: %s", name, recordColumnNames())
+		}
+		col.Name = name
+		columns[i] = col
+	}
+	return columns, nil
+}
+
+// parseRecordSort parses sort keys of the form `column[:asc|desc]`
+func parseRecordSort(keys []string) ([]recordSortKey, error) {
+	result := make([]recordSortKey, len(keys))
+	for i, key := range keys {
+		name, order, _ := strings.Cut(key, ":")
+		col, ok := recordColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort column '%s'. Available columns: %s", name, recordColumnNames())
+		}
+		switch order {
+		case "", "asc":
+		case "desc":
+			result[i].Descending = true
+		default:
+			return nil, fmt.Errorf("invalid sort order '%s' in '%s'. Must be 'asc' or 'desc'", order, key)
+		}
+		result[i].Column = col
+	}
+	return result, nil
+}
+
+func recordColumnNames() string {
+	names := maps.Keys(recordColumns)
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// sortRecords sorts records stably by the given keys
+func sortRecords(records []core.Record, keys []recordSortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, key := range keys {
+			a, b := &records[i], &records[j]
+			if key.Descending {
+				a, b = b, a
+			}
+			if key.Column.Less(a, b) {
+				return true
+			}
+			if key.Column.Less(b, a) {
+				return false
+			}
+		}
+		return false
+	})
+}
+
+// recordsTable creates a table of records with the given columns
+func recordsTable(records []core.Record, columns []recordColumn) *table.Table {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	tab := table.New(header...)
+	for i, col := range columns {
+		tab.AlignRight[i] = col.AlignRight
+	}
+	for i := range records {
+		row := make([]string, len(columns))
+		for j, col := range columns {
+			row[j] = col.Format(&records[i])
+		}
+		tab.Append(row...)
+	}
+	return tab
+}
+
+// recordTemplateFuncs are the functions available in record templates
+var recordTemplateFuncs = template.FuncMap{
+	"date": func(t time.Time) string { return t.Format(util.DateFormat) },
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return util.NoTimeString
+		}
+		return t.Format(util.TimeFormat)
+	},
+	"duration": func(d time.Duration) string { return util.FormatDuration(d) },
+	"work":     func(r *core.Record) string { return recordColumns["duration"].Format(r) },
+	"pause":    func(r *core.Record) string { return recordColumns["pause"].Format(r) },
+	"tags":     formatRecordTags,
+}
+
+// parseRecordTemplate parses a template for a single record.
+// A trailing newline is added if the template does not end with one.
+func parseRecordTemplate(text string) (*template.Template, error) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return template.New("record").Funcs(recordTemplateFuncs).Parse(text)
+}
+
+func formatRecordTags(r *core.Record) string {
+	tags := make([]string, 0, len(r.Tags))
+	for k, v := range r.Tags {
+		if v == "" {
+			tags = append(tags, k)
+		} else {
+			tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ",")
+}
+
+func noteLines(note string) []string {
+	if note == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(note, "\r\n", "\n"), "\n")
+}
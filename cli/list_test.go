@@ -41,6 +41,34 @@ func TestListWorkspaces(t *testing.T) {
 	assert.Contains(t, got, "default", "First line should contain workspace")
 }
 
+func TestListConfig(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"list", "config"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatal("error executing command")
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+
+	got := string(outStr)
+
+	assert.Contains(t, got, "maxBreakDuration", "Output should contain config keys")
+	assert.Contains(t, got, "(global)", "Output should contain config sources")
+}
+
 func TestListProjects(t *testing.T) {
 	track, err := setupTestCommand()
 	if err != nil {
@@ -231,3 +259,131 @@ func TestListRecords(t *testing.T) {
 	assert.Contains(t, got[1], "2001-02-03 06:05 - 07:05", "Wrong time range")
 	assert.Contains(t, got[1], "Test note with +tag and +foo=baz", "Wrong note")
 }
+
+func TestListRecordsRange(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	for _, day := range []int{1, 15, 28} {
+		record := core.Record{
+			Project: "test",
+			Start:   util.DateTime(2001, 2, day, 4, 5, 0),
+			End:     util.DateTime(2001, 2, day, 5, 5, 0),
+		}
+		err = track.SaveRecord(&record, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"list", "records", "2001-02"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatal("error executing command")
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+
+	got := strings.Split(strings.TrimSpace(string(outStr)), "\n")
+
+	assert.Equal(t, 3, len(got), "Wrong number of records")
+	assert.Contains(t, got[0], "2001-02-01 04:05 - 05:05", "Wrong time range")
+	assert.Contains(t, got[2], "2001-02-28 04:05 - 05:05", "Wrong time range")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"list", "records", "2001-W07"})
+
+	buffer = bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatal("error executing command")
+	}
+
+	outStr, err = io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+
+	got = strings.Split(strings.TrimSpace(string(outStr)), "\n")
+
+	assert.Equal(t, 1, len(got), "Wrong number of records")
+	assert.Contains(t, got[0], "2001-02-15 04:05 - 05:05", "Wrong time range")
+}
+
+func TestListRecordsColumns(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	for _, name := range []string{"test", "a-very-long-project-name"} {
+		project := core.NewProject(name, "", "t", []string{}, 15, 0)
+		err = track.SaveProject(project, false)
+		if err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+
+	records := []core.Record{
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 8, 0, 0), End: util.DateTime(2001, 2, 3, 9, 0, 0), Note: "Note +foo=bar", Tags: map[string]string{"foo": "bar"}},
+		{Project: "a-very-long-project-name", Start: util.DateTime(2001, 2, 3, 10, 0, 0), End: util.DateTime(2001, 2, 3, 13, 0, 0), Note: "Long", Tags: map[string]string{}},
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 14, 0, 0), End: util.DateTime(2001, 2, 3, 16, 0, 0), Tags: map[string]string{}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := RootCommand(track, "")
+		cmd.SetArgs(append([]string{"list", "records", "2001-02-03"}, args...))
+		buffer := bytes.NewBufferString("")
+		out.StdOut = buffer
+		err := cmd.Execute()
+		return buffer.String(), err
+	}
+
+	got, err := run()
+	assert.Nil(t, err)
+	assert.Contains(t, got, "a-very-long-project-name ", "Project name should not be truncated when piped")
+
+	got, err = run("--columns", "project,duration,tags", "--sort", "duration:desc", "--limit", "2")
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"a-very-long-project-name  3:00\n"+
+			"test                      2:00\n",
+		got, "Wrong columns output")
+
+	got, err = run("--template", "{{.Project}};{{time .Start}};{{work .}};{{tags .}}", "--sort", "project,start:desc")
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"a-very-long-project-name;10:00;3:00;\n"+
+			"test;14:00;2:00;\n"+
+			"test;08:00;1:00;foo=bar\n",
+		got, "Wrong template output")
+
+	_, err = run("--columns", "foo")
+	assert.NotNil(t, err, "Expected error for unknown column")
+	_, err = run("--sort", "duration:up")
+	assert.NotNil(t, err, "Expected error for invalid sort order")
+}
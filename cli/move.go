@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
@@ -25,6 +26,7 @@ func moveCommand(t *core.Track) *cobra.Command {
 	move.PersistentFlags().BoolVar(&dryRun, "dry", false, "Dry run: do not actually change any files")
 
 	move.AddCommand(moveProjectCommand(t, &dryRun))
+	move.AddCommand(moveRecordsCommand(t, &dryRun))
 
 	move.Long += "\n\n" + formatCmdTree(move)
 	return move
@@ -34,99 +36,98 @@ func moveProjectCommand(t *core.Track, dryRun *bool) *cobra.Command {
 
 	moveProject := &cobra.Command{
 		Use:   "project PROJECT WORKSPACE",
-		Short: "Move a project to another workspace",
-		Long: `Move a project to another workspace.
+		Short: "Move a project and its children to another workspace",
+		Long: `Move a project and its children to another workspace.
 
-Moves the project and all associated records to the given workspace.
-If there is no project with the same name as the parent of the project, the parent is set to none.`,
+Moves the project, all its descendants and all associated records to the given workspace.
+The parent/child structure of the moved projects is kept.
+If there is no project with the same name as the parent of the project, the parent is set to none.
+
+Nothing is moved if any of the projects or records already exists in the target workspace.`,
 		Aliases: []string{"p"},
 		Args:    util.WrappedArgs(cobra.ExactArgs(2)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 			workspace := args[1]
 
-			project, err := t.LoadProject(name)
+			numProjects, numRecords, err := t.MoveProjects([]string{name}, workspace, *dryRun)
 			if err != nil {
 				return fmt.Errorf("failed to move project: %s", err)
 			}
 
-			projects, err := t.LoadAllProjects()
-			if err != nil {
-				return fmt.Errorf("failed to move project: %s", err)
-			}
-			pTree, err := t.ToProjectTree(projects)
-			if err != nil {
-				return fmt.Errorf("failed to move project: %s", err)
-			}
-			pNode, ok := pTree.Nodes[name]
-			if !ok {
-				return fmt.Errorf("failed to move project: no project named '%s'", name)
-			}
-			if len(pNode.Children) > 0 {
-				return fmt.Errorf("failed to move project: '%s' has %d child project(s)", name, len(pNode.Children))
-			}
-
-			if !t.WorkspaceExists(workspace) {
-				return fmt.Errorf("failed to move project: workspace '%s' does not exist", workspace)
-			}
-			if t.Workspace() == workspace {
-				return fmt.Errorf("failed to move project: project is already in workspace '%s'", workspace)
+			if *dryRun {
+				out.Success("Moved project '%s' to workspace '%s' (%d projects, %d records) - dry-run", name, workspace, numProjects, numRecords)
+			} else {
+				out.Success("Moved project '%s' to workspace '%s' (%d projects, %d records)", name, workspace, numProjects, numRecords)
 			}
+			return nil
+		},
+	}
 
-			prevWorkspace := t.Config.Workspace
-			t.Config.Workspace = workspace
+	return moveProject
+}
 
-			if t.ProjectExists(name) {
-				return fmt.Errorf("failed to move project: a project '%s' already exists in workspace '%s'", name, workspace)
-			}
-			if t.ProjectExists(project.Parent) {
-				project.Parent = ""
-			}
+func moveRecordsCommand(t *core.Track, dryRun *bool) *cobra.Command {
+	options := filterOptions{}
+	var where []string
 
-			t.Config.Workspace = prevWorkspace
+	moveRecords := &cobra.Command{
+		Use:   "records WORKSPACE",
+		Short: "Move records to another workspace",
+		Long: `Move records to another workspace.
 
-			filters := core.NewFilter(
-				[]core.FilterFunction{
-					core.FilterByProjects([]string{project.Name}),
-				}, util.NoTime, util.NoTime,
-			)
+Moves all records matching the filters to the given workspace.
+The projects of the records must exist in the target workspace.
 
-			records, err := t.LoadAllRecordsFiltered(filters)
-			if err != nil {
-				return fmt.Errorf("failed to move project: %s", err)
-			}
+Nothing is moved if any of the records already exists in the target workspace.
 
-			t.Config.Workspace = workspace
+Example:
 
-			if !*dryRun {
-				err = t.SaveProject(project, false)
-				if err != nil {
-					return fmt.Errorf("failed to move project: %s", err)
-				}
+  track move records --where client=acme --start 2023-01-01 acme`,
+		Aliases: []string{"r"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workspace := args[0]
 
-				for _, rec := range records {
-					err = t.SaveRecord(&rec, false)
-					if err != nil {
-						return fmt.Errorf("failed to move project: %s", err)
-					}
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to move records: %s", err)
+			}
+			filters, err := createFilters(&options, projects, true, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to move records: %s", err)
+			}
+			if len(where) > 0 {
+				tags := make([]util.Pair[string, string], len(where))
+				for i, tag := range where {
+					k, v := core.ParseTag(strings.TrimPrefix(tag, core.TagPrefix))
+					tags[i] = util.NewPair(k, v)
 				}
+				filters.Functions = append(filters.Functions, core.FilterByTagsAll(tags))
 			}
 
-			t.Config.Workspace = prevWorkspace
-
-			_, err = t.DeleteProject(&project, true, *dryRun)
+			records, err := t.LoadAllRecordsFiltered(filters)
 			if err != nil {
-				return fmt.Errorf("failed to move project: %s", err)
+				return fmt.Errorf("failed to move records: %s", err)
+			}
+			if err := t.MoveRecords(records, workspace, *dryRun); err != nil {
+				return fmt.Errorf("failed to move records: %s", err)
 			}
 
 			if *dryRun {
-				out.Success("Moved project '%s' to workspace '%s' (%d records) - dry-run", name, workspace, len(records))
+				out.Success("Moved %d records to workspace '%s' - dry-run", len(records), workspace)
 			} else {
-				out.Success("Moved project '%s' to workspace '%s' (%d records)", name, workspace, len(records))
+				out.Success("Moved %d records to workspace '%s'", len(records), workspace)
 			}
 			return nil
 		},
 	}
 
-	return moveProject
+	moveRecords.Flags().StringSliceVarP(&options.projects, "projects", "p", []string{}, "Projects to include (comma-separated). All projects if not specified")
+	moveRecords.Flags().StringSliceVarP(&where, "where", "w", []string{}, "Tags the records must have (comma-separated), like 'client=acme'. Includes records with all of the given tags")
+	moveRecords.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	moveRecords.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	moveRecords.Flags().BoolVarP(&options.includeArchived, "archived", "a", false, "Include records from archived projects")
+
+	return moveRecords
 }
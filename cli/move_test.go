@@ -70,3 +70,141 @@ func TestMove(t *testing.T) {
 	}
 	assert.Equal(t, 2, len(all), "Wrong number of records")
 }
+
+func TestMoveSubtree(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	err = track.CreateWorkspace("move-to")
+	if err != nil {
+		t.Fatal("error creating workspace")
+	}
+
+	for _, p := range []core.Project{
+		core.NewProject("parent", "", "p", []string{}, 15, 0),
+		core.NewProject("child", "parent", "c", []string{}, 15, 0),
+		core.NewProject("grandchild", "child", "g", []string{}, 15, 0),
+	} {
+		if err := track.SaveProject(p, false); err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+	record := core.Record{
+		Project: "grandchild",
+		Start:   util.DateTime(2001, 2, 3, 4, 5, 0),
+		End:     util.DateTime(2001, 2, 3, 5, 5, 0),
+	}
+	if err := track.SaveRecord(&record, false); err != nil {
+		t.Fatal("error saving record")
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"move", "project", "child", "move-to"})
+	err = cmd.Execute()
+	assert.Nil(t, err)
+
+	assert.True(t, track.ProjectExists("parent"), "Parent should stay")
+	assert.False(t, track.ProjectExists("child"), "Child should be moved")
+	assert.False(t, track.ProjectExists("grandchild"), "Grandchild should be moved")
+
+	err = track.SwitchWorkspace("move-to")
+	if err != nil {
+		t.Fatal("error switching workspace")
+	}
+	child, err := track.LoadProject("child")
+	assert.Nil(t, err)
+	assert.Equal(t, "", child.Parent, "Missing parent should be removed")
+	grandchild, err := track.LoadProject("grandchild")
+	assert.Nil(t, err)
+	assert.Equal(t, "child", grandchild.Parent, "Structure should be kept")
+
+	all, err := track.LoadAllRecords()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(all), "Wrong number of records")
+}
+
+func TestMoveRecords(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	err = track.CreateWorkspace("move-to")
+	if err != nil {
+		t.Fatal("error creating workspace")
+	}
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	if err := track.SaveProject(project, false); err != nil {
+		t.Fatal("error saving project")
+	}
+	records := []core.Record{
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 4, 5, 0), End: util.DateTime(2001, 2, 3, 5, 5, 0), Note: "+client=acme"},
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 6, 5, 0), End: util.DateTime(2001, 2, 3, 7, 5, 0), Note: "+client=acme"},
+		{Project: "test", Start: util.DateTime(2001, 2, 4, 6, 5, 0), End: util.DateTime(2001, 2, 4, 7, 5, 0), Note: "+client=other"},
+	}
+	for i := range records {
+		if err := track.SaveRecord(&records[i], false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"move", "records", "--where", "client=acme", "move-to"})
+	err = cmd.Execute()
+	assert.NotNil(t, err, "Should fail when the project is missing in the target workspace")
+
+	all, err := track.LoadAllRecords()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(all), "No records should be moved")
+
+	err = track.SwitchWorkspace("move-to")
+	if err != nil {
+		t.Fatal("error switching workspace")
+	}
+	if err := track.SaveProject(project, false); err != nil {
+		t.Fatal("error saving project")
+	}
+	collision := records[1]
+	if err := track.SaveRecord(&collision, false); err != nil {
+		t.Fatal("error saving record")
+	}
+	err = track.SwitchWorkspace("default")
+	if err != nil {
+		t.Fatal("error switching workspace")
+	}
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"move", "records", "--where", "client=acme", "move-to"})
+	err = cmd.Execute()
+	assert.NotNil(t, err, "Should fail on collision")
+
+	all, err = track.LoadAllRecords()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(all), "No records should be moved")
+
+	err = track.SwitchWorkspace("move-to")
+	if err != nil {
+		t.Fatal("error switching workspace")
+	}
+	if err := track.DeleteRecord(&collision, ""); err != nil {
+		t.Fatal("error deleting record")
+	}
+	err = track.SwitchWorkspace("default")
+	if err != nil {
+		t.Fatal("error switching workspace")
+	}
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"move", "records", "--where", "client=acme", "move-to"})
+	err = cmd.Execute()
+	assert.Nil(t, err)
+
+	all, err = track.LoadAllRecords()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(all), "Wrong number of remaining records")
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func renameCommand(t *core.Track) *cobra.Command {
+	rename := &cobra.Command{
+		Use:   "rename",
+		Short: "Rename a resource",
+		Long:  "Rename a resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	rename.AddCommand(renameWorkspaceCommand(t))
+
+	rename.Long += "\n\n" + formatCmdTree(rename)
+	return rename
+}
+
+func renameWorkspaceCommand(t *core.Track) *cobra.Command {
+	renameWorkspace := &cobra.Command{
+		Use:     "workspace OLD NEW",
+		Short:   "Rename a workspace",
+		Long:    "Rename a workspace",
+		Aliases: []string{"w"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldName, newName := args[0], args[1]
+			if err := t.RenameWorkspace(oldName, newName); err != nil {
+				return fmt.Errorf("failed to rename workspace: %s", err)
+			}
+			out.Success("Renamed workspace '%s' to '%s'", oldName, newName)
+			return nil
+		},
+	}
+
+	return renameWorkspace
+}
@@ -21,6 +21,9 @@ func reportCommand(t *core.Track) *cobra.Command {
 	report.PersistentFlags().StringSliceVarP(&options.projects, "projects", "p", []string{}, "Projects to include (comma-separated). All projects if not specified")
 	report.PersistentFlags().StringSliceVarP(&options.tags, "tags", "t", []string{}, "Tags to include (comma-separated). Includes records with any of the given tags")
 	report.PersistentFlags().BoolVarP(&options.includeArchived, "archived", "a", false, "Include records from archived projects")
+	report.PersistentFlags().BoolVar(&options.round, "round", false, "Apply the rounding policies of the config and projects to the reported times")
+
+	addWorkspaceFlags(t, report, true)
 
 	report.AddCommand(timelineReportCommand(t, &options))
 	report.AddCommand(projectsReportCommand(t, &options))
@@ -29,6 +32,12 @@ func reportCommand(t *core.Track) *cobra.Command {
 	report.AddCommand(weekReportCommand(t, &options))
 	report.AddCommand(dayReportCommand(t, &options))
 	report.AddCommand(treemapReportCommand(t, &options))
+	report.AddCommand(htmlReportCommand(t, &options))
+	report.AddCommand(digestReportCommand(t, &options))
+	report.AddCommand(compareReportCommand(t, &options))
+	report.AddCommand(heatmapReportCommand(t, &options))
+	report.AddCommand(distributionReportCommand(t, &options))
+	report.AddCommand(pivotReportCommand(t, &options))
 
 	report.Long += "\n\n" + formatCmdTree(report)
 	return report
@@ -6,26 +6,30 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"github.com/gookit/color"
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/chart"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
 )
 
 func chartReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	var blocksPerHour int
+	var image imageOptions
 
 	day := &cobra.Command{
-		Use:     "chart [DATE]",
-		Short:   "Report of activities over the day as a bar chart per project",
+		Use:   "chart [DATE]",
+		Short: "Report of activities over the day as a bar chart per project",
+		Long: `Report of activities over the day as a bar chart per project
+
+Use flags --svg or --png for a Gantt-style chart with one row per project, with hatched pauses.`,
 		Aliases: []string{"c"},
 		Args:    util.WrappedArgs(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			start := util.ToDate(time.Now())
 			var err error
 			if len(args) > 0 {
-				start, err = util.ParseDate(args[0])
+				start, err = util.ParseDate(args[0], t.Config.Calendar())
 				if err != nil {
 					return fmt.Errorf("failed to generate report: %s", err)
 				}
@@ -33,6 +37,9 @@ func chartReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 			if blocksPerHour <= 0 {
 				return fmt.Errorf("failed to generate report: argument --width must be > 0")
 			}
+			if err := image.check(); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err)
+			}
 			if !cmd.Flags().Changed("width") {
 				if w, _, err := util.TerminalSize(); err == nil && w > 0 {
 					blocksPerHour = (w - 29) / 24
@@ -47,16 +54,28 @@ func chartReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 			filterStart := start.Add(-time.Hour * 24)
 			filterEnd := start.Add(time.Hour * 24)
 
-			filters, err := createFilters(options, projects, false)
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
 			filters = core.NewFilter(filters.Functions, filterStart, filterEnd)
 
-			reporter, err := core.NewReporter(t, options.projects, filters, options.includeArchived, start, filterEnd)
+			reporter, err := createReporter(t, options, filters, start, filterEnd)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
+			if image.enabled() {
+				tree, err := t.ToProjectTree(reporter.Projects)
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err)
+				}
+				title := fmt.Sprintf("Day %s", start.Format(util.DateFormat))
+				if err := renderImage(chart.DayChart(title, reporter, tree, start), &image); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err)
+				}
+				return nil
+			}
+
 			var active string
 			rec, err := t.OpenRecord()
 			if err != nil {
@@ -76,6 +95,7 @@ func chartReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	}
 
 	day.Flags().IntVarP(&blocksPerHour, "width", "w", 3, "Width of the graph, in characters per hour. Auto-scale if not specified")
+	addImageFlags(day, &image)
 
 	return day
 }
@@ -150,7 +170,7 @@ func renderDayChart(t *core.Track, reporter *core.Reporter, active string, start
 		}
 		timelineStr[pr] = toDayChart(runes, interval*bph)
 	}
-	timelineStr[t.WorkspaceLabel()] = toDayChartAxis(bph, interval*bph)
+	timelineStr[reporter.ProjectsTree.Root.Value.Name] = toDayChartAxis(bph, interval*bph)
 
 	formatter := util.NewTreeFormatter(
 		func(t *core.ProjectNode, indent int) string {
@@ -162,7 +182,7 @@ func renderDayChart(t *core.Track, reporter *core.Reporter, active string, start
 			}
 			var str string
 			if t.Value.Name == active {
-				str = color.BgBlue.Sprintf("%s", name)
+				str = out.Highlight(name)
 			} else {
 				str = name
 			}
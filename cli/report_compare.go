@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	compareNew      = "new"
+	compareVanished = "vanished"
+)
+
+type compareRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type compareEntry struct {
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Parent   string   `json:"parent,omitempty"`
+	MinutesA float64  `json:"minutesA"`
+	MinutesB float64  `json:"minutesB"`
+	Diff     float64  `json:"diff"`
+	Relative *float64 `json:"relative"`
+	Status   string   `json:"status,omitempty"`
+
+	a time.Duration
+	b time.Duration
+}
+
+type comparison struct {
+	A        compareRange   `json:"a"`
+	B        compareRange   `json:"b"`
+	Projects []compareEntry `json:"projects"`
+	Tags     []compareEntry `json:"tags"`
+}
+
+func compareReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var rangeA string
+	var rangeB string
+	var csvOut bool
+	var jsonOut bool
+
+	compare := &cobra.Command{
+		Use:   "compare",
+		Short: "Compares time per project and tag between two time ranges",
+		Long: `Compares time per project and tag between two time ranges
+
+Time ranges are given as date expressions with flags --a and --b,
+like "last month", "2023-09" or "2023-W14". Example:
+
+  track report compare --a 2023-09 --b 2023-10
+
+Shows the project tree with the totals of both ranges, and absolute and relative differences.
+Projects and tags without time in the first range are marked as new,
+those without time in the second range are marked as vanished.`,
+		Aliases: []string{"C"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			startA, endA, err := util.ParseDateRange(rangeA, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			startB, endB, err := util.ParseDateRange(rangeB, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			reporterA, err := periodReporter(t, options, startA, endA)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			reporterB, err := periodReporter(t, options, startB, endB)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			comp := compare(reporterA, reporterB)
+			comp.A = compareRange{Start: startA.Format(util.DateFormat), End: endA.Add(-time.Second).Format(util.DateFormat)}
+			comp.B = compareRange{Start: startB.Format(util.DateFormat), End: endB.Add(-time.Second).Format(util.DateFormat)}
+
+			if jsonOut {
+				bytes, err := json.MarshalIndent(comp, "", "    ")
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				out.Print("%s\n", bytes)
+				return nil
+			}
+			if csvOut {
+				text, err := renderCompareCsv(&comp)
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				out.Print("%s", text)
+				return nil
+			}
+
+			tree, err := compareTree(t, reporterB, &comp)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			out.Print("%s", renderCompare(&comp, tree))
+			return nil
+		},
+	}
+
+	compare.Flags().StringVar(&rangeA, "a", "last month", "First time range, as a date expression")
+	compare.Flags().StringVar(&rangeB, "b", "this month", "Second time range, as a date expression")
+	compare.Flags().BoolVar(&csvOut, "csv", false, "Report in CSV format")
+	compare.Flags().BoolVar(&jsonOut, "json", false, "Report in JSON format")
+	compare.MarkFlagsMutuallyExclusive("csv", "json")
+
+	return compare
+}
+
+// compare compares the project and tag totals of two reporters
+func compare(a, b *core.Reporter) comparison {
+	comp := comparison{Projects: []compareEntry{}, Tags: []compareEntry{}}
+
+	root := b.ProjectsTree.Root.Value.Name
+	names := map[string]bool{root: true}
+	for name, dur := range a.TotalTime {
+		if dur > 0 {
+			names[name] = true
+		}
+	}
+	for name, dur := range b.TotalTime {
+		if dur > 0 {
+			names[name] = true
+		}
+	}
+	projects := maps.Keys(names)
+	sort.Strings(projects)
+	for _, name := range projects {
+		parent := ""
+		if node, ok := b.ProjectsTree.Nodes[name]; ok && node.Parent != nil {
+			parent = node.Parent.Value.Name
+		}
+		entry := newCompareEntry("project", name, a.TotalTime[name], b.TotalTime[name])
+		entry.Parent = parent
+		comp.Projects = append(comp.Projects, entry)
+	}
+
+	tagsA := a.TagStats(nil)
+	tagsB := b.TagStats(nil)
+	tagNames := map[string]bool{}
+	for tag := range tagsA {
+		tagNames[tag] = true
+	}
+	for tag := range tagsB {
+		tagNames[tag] = true
+	}
+	tags := maps.Keys(tagNames)
+	sort.Strings(tags)
+	for _, tag := range tags {
+		var durA, durB time.Duration
+		if st, ok := tagsA[tag]; ok {
+			durA = st.Work
+		}
+		if st, ok := tagsB[tag]; ok {
+			durB = st.Work
+		}
+		comp.Tags = append(comp.Tags, newCompareEntry("tag", tag, durA, durB))
+	}
+
+	return comp
+}
+
+func newCompareEntry(tp, name string, a, b time.Duration) compareEntry {
+	entry := compareEntry{
+		Type:     tp,
+		Name:     name,
+		MinutesA: a.Minutes(),
+		MinutesB: b.Minutes(),
+		Diff:     (b - a).Minutes(),
+		a:        a,
+		b:        b,
+	}
+	if a > 0 {
+		rel := float64(b-a) / float64(a)
+		entry.Relative = &rel
+	}
+	if a <= 0 && b > 0 {
+		entry.Status = compareNew
+	} else if a > 0 && b <= 0 {
+		entry.Status = compareVanished
+	}
+	return entry
+}
+
+// compareTree creates a tree of all compared projects
+func compareTree(t *core.Track, r *core.Reporter, comp *comparison) (*core.ProjectTree, error) {
+	projects := map[string]core.Project{}
+	for _, e := range comp.Projects {
+		if p, ok := r.AllProjects[e.Name]; ok {
+			projects[p.Name] = p
+		}
+	}
+	return t.ToProjectTree(projects)
+}
+
+func renderCompare(comp *comparison, tree *core.ProjectTree) string {
+	entries := map[string]*compareEntry{}
+	for i := range comp.Projects {
+		entries[comp.Projects[i].Name] = &comp.Projects[i]
+	}
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "A: %s - %s\nB: %s - %s\n\n", comp.A.Start, comp.A.End, comp.B.Start, comp.B.End)
+	fmt.Fprintf(&sb, "%-20s %6s %6s %7s %6s\n", "", "A", "B", "diff", "%")
+
+	formatter := util.NewTreeFormatter(
+		func(t *core.ProjectNode, indent int) string {
+			fillLen := 16 - (indent + utf8.RuneCountInString(t.Value.Name))
+			name := t.Value.Name
+			if fillLen < 0 {
+				nameRunes := []rune(name)
+				name = string(nameRunes[:len(nameRunes)+fillLen-1]) + "."
+			}
+			str := name
+			if fillLen > 0 {
+				str += strings.Repeat(" ", fillLen)
+			}
+			str += " "
+			str += t.Value.Render.Sprintf(" %s ", t.Value.Symbol)
+
+			e, ok := entries[t.Value.Name]
+			if !ok {
+				return str
+			}
+			return str + formatCompareValues(e)
+		},
+		2,
+	)
+	sb.WriteString(formatter.FormatTree(tree))
+
+	if len(comp.Tags) > 0 {
+		fmt.Fprintf(&sb, "\n")
+		for i := range comp.Tags {
+			e := &comp.Tags[i]
+			name := core.TagPrefix + e.Name
+			fillLen := 20 - utf8.RuneCountInString(name)
+			if fillLen < 0 {
+				nameRunes := []rune(name)
+				name = string(nameRunes[:len(nameRunes)+fillLen-1]) + "."
+				fillLen = 0
+			}
+			fmt.Fprintf(&sb, "%s%s%s\n", name, strings.Repeat(" ", fillLen), formatCompareValues(e))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatCompareValues(e *compareEntry) string {
+	diff := e.b - e.a
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	rel := ""
+	if e.Relative != nil {
+		rel = fmt.Sprintf("%+.0f%%", 100**e.Relative)
+	}
+	str := fmt.Sprintf(
+		" %6s %6s %7s %6s",
+		util.FormatDuration(e.a, false), util.FormatDuration(e.b, false),
+		sign+util.FormatDuration(diff, false), rel,
+	)
+	if e.Status != "" {
+		str += " " + e.Status
+	}
+	return str
+}
+
+func renderCompareCsv(comp *comparison) (string, error) {
+	sb := strings.Builder{}
+	writer := csv.NewWriter(&sb)
+	if err := writer.Write([]string{"type", "name", "parent", "a", "b", "diff", "relative", "status"}); err != nil {
+		return "", err
+	}
+	for _, entries := range [][]compareEntry{comp.Projects, comp.Tags} {
+		for _, e := range entries {
+			diff := e.b - e.a
+			sign := ""
+			if diff < 0 {
+				sign = "-"
+				diff = -diff
+			}
+			rel := ""
+			if e.Relative != nil {
+				rel = fmt.Sprintf("%.4f", *e.Relative)
+			}
+			err := writer.Write([]string{
+				e.Type, e.Name, e.Parent,
+				util.FormatDuration(e.a), util.FormatDuration(e.b), sign + util.FormatDuration(diff),
+				rel, e.Status,
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	writer.Flush()
+	return sb.String(), writer.Error()
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCompare(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	for _, name := range []string{"p1", "p2"} {
+		project := core.NewProject(name, "", "p", []string{}, 15, 0)
+		err = track.SaveProject(project, false)
+		if err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 2, 7, 10, 0, 0), End: util.DateTime(2023, 2, 7, 12, 0, 0), Note: "+foo", Tags: map[string]string{"foo": ""}},
+		{Project: "p1", Start: util.DateTime(2023, 3, 14, 10, 0, 0), End: util.DateTime(2023, 3, 14, 13, 0, 0), Tags: map[string]string{}},
+		{Project: "p2", Start: util.DateTime(2023, 3, 15, 10, 0, 0), End: util.DateTime(2023, 3, 15, 11, 0, 0), Note: "+bar +a,b", Tags: map[string]string{"bar": "", "a,b": ""}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"report", "compare", "--a", "2023-02", "--b", "2023-03", "--csv"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+	got := string(outStr)
+
+	assert.Contains(t, got, "project,p1,<default>,02:00,03:00,01:00,0.5000,\n", "Wrong project entry")
+	assert.Contains(t, got, "project,p2,<default>,00:00,01:00,01:00,,new\n", "Wrong new project entry")
+	assert.Contains(t, got, "tag,foo,,02:00,00:00,-02:00,-1.0000,vanished\n", "Wrong vanished tag entry")
+	assert.Contains(t, got, "tag,bar,,00:00,01:00,01:00,,new\n", "Wrong new tag entry")
+	assert.Contains(t, got, "tag,\"a,b\",,00:00,01:00,01:00,,new\n", "Tag with comma should be quoted")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"report", "compare", "--a", "2023-02", "--b", "2023-03", "--csv", "--json"})
+	err = cmd.Execute()
+	assert.NotNil(t, err, "should fail with mutually exclusive flags")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/chart"
 	"github.com/mlange-42/track/render/schedule"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ import (
 func weekReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	var blocksPerHour int
 	var exact bool
+	var image imageOptions
 
 	week := &cobra.Command{
 		Use:   "week [DATE]",
@@ -23,39 +25,46 @@ func weekReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 
 Reports for the current week if no date is given, or for the past 7 days with flag --7days.
 
-If called with a date, reports for the week containing the date, or for the 7 days starting with the date with flag --7days.`,
+If called with a date, reports for the week containing the date, or for the 7 days starting with the date with flag --7days.
+
+Use flags --svg or --png for a Gantt-style chart, with hatched pauses.`,
 		Aliases: []string{"w"},
 		Args:    util.WrappedArgs(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			start := util.ToDate(time.Now())
+			cal := t.Config.Calendar()
 
 			var err error
 			if len(args) > 0 {
-				start, err = util.ParseDate(args[0])
+				// Any day of the week, so ranges like "2023-W14" resolve to their first day
+				start, _, err = util.ParseDateRange(args[0], cal)
 				if err != nil {
 					return fmt.Errorf("failed to generate report: %s", err)
 				}
 				if !exact {
-					start = util.Monday(start)
+					start = cal.WeekStart(start)
 				}
 			} else {
 				if exact {
 					start = start.Add(-6 * 24 * time.Hour)
 				} else {
-					start = util.Monday(start)
+					start = cal.WeekStart(start)
 				}
 			}
 
 			if blocksPerHour <= 0 {
 				return fmt.Errorf("failed to generate report: argument --width must be > 0")
 			}
+			if err := image.check(); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err)
+			}
 			if !cmd.Flags().Changed("width") {
 				if w, _, err := util.TerminalSize(); err == nil && w > 0 {
 					blocksPerHour = (w - 14) / 7
 				}
 			}
 
-			err = renderSchedule(t, start, options, true, blocksPerHour)
+			err = renderSchedule(t, start, options, true, blocksPerHour, &image)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
@@ -65,16 +74,21 @@ If called with a date, reports for the week containing the date, or for the 7 da
 
 	week.Flags().IntVarP(&blocksPerHour, "width", "w", 12, "Width of the graph, in characters per hour. Auto-scale if not specified")
 	week.Flags().BoolVarP(&exact, "7days", "7", false, "Show the report for 7 days instead of the current/given calendar week")
+	addImageFlags(week, &image)
 
 	return week
 }
 
 func dayReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	var blocksPerHour int
+	var image imageOptions
 
 	day := &cobra.Command{
-		Use:     "day [DATE]",
-		Short:   "Report of activities over a day in the form of a schedule",
+		Use:   "day [DATE]",
+		Short: "Report of activities over a day in the form of a schedule",
+		Long: `Report of activities over a day in the form of a schedule
+
+Use flags --svg or --png for a Gantt-style chart, with hatched pauses.`,
 		Aliases: []string{"d"},
 		Args:    util.WrappedArgs(cobra.MaximumNArgs(1)),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -82,7 +96,7 @@ func dayReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 
 			var err error
 			if len(args) > 0 {
-				start, err = util.ParseDate(args[0])
+				start, err = util.ParseDate(args[0], t.Config.Calendar())
 				if err != nil {
 					out.Err("failed to generate report: %s", err)
 					return
@@ -93,13 +107,17 @@ func dayReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 				out.Err("failed to generate report: argument --width must be > 0")
 				return
 			}
+			if err := image.check(); err != nil {
+				out.Err("failed to generate report: %s", err)
+				return
+			}
 			if !cmd.Flags().Changed("width") {
 				if w, _, err := util.TerminalSize(); err == nil && w > 0 {
 					blocksPerHour = (w - 8)
 				}
 			}
 
-			err = renderSchedule(t, start, options, false, blocksPerHour)
+			err = renderSchedule(t, start, options, false, blocksPerHour, &image)
 			if err != nil {
 				out.Err("failed to generate report: %s", err)
 				return
@@ -108,11 +126,12 @@ func dayReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	}
 
 	day.Flags().IntVarP(&blocksPerHour, "width", "w", 60, "Width of the graph, in characters per hour. Auto-scale if not specified")
+	addImageFlags(day, &image)
 
 	return day
 }
 
-func renderSchedule(t *core.Track, start time.Time, options *filterOptions, week bool, bph int) error {
+func renderSchedule(t *core.Track, start time.Time, options *filterOptions, week bool, bph int, image *imageOptions) error {
 	var filterStart, filterEnd time.Time
 
 	if week {
@@ -128,17 +147,27 @@ func renderSchedule(t *core.Track, start time.Time, options *filterOptions, week
 		return err
 	}
 
-	filters, err := createFilters(options, projects, false)
+	filters, err := createFilters(options, projects, false, t.Config.Calendar())
 	if err != nil {
 		return err
 	}
 	filters = core.NewFilter(filters.Functions, filterStart, filterEnd)
 
-	reporter, err := core.NewReporter(t, options.projects, filters, options.includeArchived, start, filterEnd)
+	reporter, err := createReporter(t, options, filters, start, filterEnd)
 	if err != nil {
 		return err
 	}
 
+	if image.enabled() {
+		days := 1
+		title := fmt.Sprintf("Day %s", start.Format(util.DateFormat))
+		if week {
+			days = 7
+			title = fmt.Sprintf("Week %s - %s", start.Format(util.DateFormat), util.AddDays(start, 6).Format(util.DateFormat))
+		}
+		return renderImage(chart.Schedule(title, reporter, start, days), image)
+	}
+
 	renderer := schedule.TextRenderer{
 		Track:         t,
 		Reporter:      reporter,
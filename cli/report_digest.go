@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+type digestOptions struct {
+	period  string
+	date    string
+	top     int
+	eml     bool
+	from    string
+	to      string
+	subject string
+}
+
+type digestEntry struct {
+	Name     string
+	Time     time.Duration
+	Previous time.Duration
+	Count    int
+}
+
+func digestReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	digestOpt := digestOptions{}
+
+	digest := &cobra.Command{
+		Use:   "digest",
+		Short: "Generates a Markdown summary of a week, month or period",
+		Long: `Generates a Markdown summary of a week, month or period
+
+The digest contains totals per top-level project compared to the previous period,
+the projects with the most time, the most used tags, and the days with the longest hours.
+
+Use flag --eml to generate a plain-text e-mail message instead,
+which can be saved as an .eml file or piped to sendmail:
+
+  track report digest --date "last week" --eml --to me@example.com | sendmail -t`,
+		Aliases: []string{"g"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cal := t.Config.Calendar()
+			// Any day of the period, so ranges like "last month" resolve to their first day
+			date, _, err := util.ParseDateRange(digestOpt.date, cal)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			start, end, err := cal.PeriodRange(digestOpt.period, date, 0)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			prevStart, prevEnd, err := cal.PeriodRange(digestOpt.period, date, -1)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			reporter, err := periodReporter(t, options, start, end)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			prevReporter, err := periodReporter(t, options, prevStart, prevEnd)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			title := fmt.Sprintf(
+				"Digest for %s %s - %s",
+				digestOpt.period, start.Format(util.DateFormat), end.Add(-time.Second).Format(util.DateFormat),
+			)
+			text := renderDigest(title, reporter, prevReporter, start, end, digestOpt.top)
+
+			if !digestOpt.eml {
+				out.Print("%s", text)
+				return nil
+			}
+
+			subject := digestOpt.subject
+			if subject == "" {
+				subject = title
+			}
+			out.Print("%s", formatMail(digestOpt.from, digestOpt.to, subject, text))
+			return nil
+		},
+	}
+
+	digest.Flags().StringVar(&digestOpt.period, "period", "week", "Length of the digest period: week, month, quarter, year or period (custom period from the config)")
+	digest.Flags().StringVar(&digestOpt.date, "date", "today", "A date in the period to summarize")
+	digest.Flags().IntVar(&digestOpt.top, "top", 5, "Number of entries in top-lists")
+	digest.Flags().BoolVar(&digestOpt.eml, "eml", false, "Generate a plain-text e-mail message")
+	digest.Flags().StringVar(&digestOpt.from, "from", "", "Sender of the e-mail message")
+	digest.Flags().StringVar(&digestOpt.to, "to", "", "Recipient of the e-mail message")
+	digest.Flags().StringVar(&digestOpt.subject, "subject", "", "Subject of the e-mail message. Default: title of the digest")
+
+	return digest
+}
+
+// periodReporter creates a reporter for the given time range, with all other filters from the options
+func periodReporter(t *core.Track, options *filterOptions, start, end time.Time) (*core.Reporter, error) {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	filters, err := createFilters(options, projects, false, t.Config.Calendar())
+	if err != nil {
+		return nil, err
+	}
+	filters = core.NewFilter(filters.Functions, start, end)
+
+	return createReporter(t, options, filters, start, end)
+}
+
+func renderDigest(title string, r, prev *core.Reporter, start, end time.Time, top int) string {
+	sb := strings.Builder{}
+	root := r.ProjectsTree.Root.Value.Name
+
+	total := r.TotalTime[root]
+	prevTotal := prev.TotalTime[root]
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+	fmt.Fprintf(&sb, "Total: **%s** (previous: %s, %s)\n", util.FormatDuration(total), util.FormatDuration(prevTotal), formatChange(total, prevTotal))
+
+	// Totals per top-level project
+	projects := []digestEntry{}
+	for _, child := range r.ProjectsTree.Root.Children {
+		name := child.Value.Name
+		entry := digestEntry{Name: name, Time: r.TotalTime[name], Previous: prev.TotalTime[name]}
+		if entry.Time > 0 || entry.Previous > 0 {
+			projects = append(projects, entry)
+		}
+	}
+	sortDigestEntries(projects)
+
+	fmt.Fprintf(&sb, "\n## Projects\n\n")
+	if len(projects) == 0 {
+		fmt.Fprintf(&sb, "No records\n")
+	} else {
+		fmt.Fprintf(&sb, "| Project | Time | Previous | Change |\n")
+		fmt.Fprintf(&sb, "|---|--:|--:|--:|\n")
+		for _, e := range projects {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", e.Name, util.FormatDuration(e.Time), util.FormatDuration(e.Previous), formatChange(e.Time, e.Previous))
+		}
+	}
+
+	// Projects with the most own time
+	sinks := []digestEntry{}
+	for name, dur := range r.ProjectTime {
+		if name != root && dur > 0 {
+			sinks = append(sinks, digestEntry{Name: name, Time: dur})
+		}
+	}
+	sortDigestEntries(sinks)
+	if len(sinks) > 0 {
+		fmt.Fprintf(&sb, "\n## Biggest time sinks\n\n")
+		for i, e := range sinks {
+			if i >= top {
+				break
+			}
+			fmt.Fprintf(&sb, "%d. %s: %s (%.0f%%)\n", i+1, e.Name, util.FormatDuration(e.Time), 100*float64(e.Time)/float64(total))
+		}
+	}
+
+	// Most used tags
+	tagStats := r.TagStats(nil)
+	tags := []digestEntry{}
+	for _, tag := range maps.Keys(tagStats) {
+		tags = append(tags, digestEntry{Name: tag, Time: tagStats[tag].Work, Count: tagStats[tag].Count})
+	}
+	sortDigestEntries(tags)
+	if len(tags) > 0 {
+		fmt.Fprintf(&sb, "\n## Top tags\n\n")
+		for i, e := range tags {
+			if i >= top {
+				break
+			}
+			fmt.Fprintf(&sb, "%d. %s%s: %s (%d records)\n", i+1, core.TagPrefix, e.Name, util.FormatDuration(e.Time), e.Count)
+		}
+	}
+
+	// Longest days
+	days := []digestEntry{}
+	if len(r.Records) > 0 {
+		data := timelineDays(r)
+		for i, date := range data.dates {
+			if data.values[i] > 0 && !date.Before(start) && date.Before(end) {
+				days = append(days, digestEntry{Name: date.Format("Mon " + util.DateFormat), Time: data.values[i]})
+			}
+		}
+	}
+	sortDigestEntries(days)
+	if len(days) > 0 {
+		fmt.Fprintf(&sb, "\n## Longest days\n\n")
+		for i, e := range days {
+			if i >= top {
+				break
+			}
+			fmt.Fprintf(&sb, "%d. %s: %s\n", i+1, e.Name, util.FormatDuration(e.Time))
+		}
+	}
+
+	return sb.String()
+}
+
+// sortDigestEntries sorts entries by time, in descending order
+func sortDigestEntries(entries []digestEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Time == entries[j].Time {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Time > entries[j].Time
+	})
+}
+
+// formatChange formats the change of a duration compared to a previous duration
+func formatChange(curr, prev time.Duration) string {
+	diff := curr - prev
+	sign := "+"
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	if prev <= 0 {
+		return fmt.Sprintf("%s%s", sign, util.FormatDuration(diff))
+	}
+	return fmt.Sprintf("%s%s / %s%.0f%%", sign, util.FormatDuration(diff), sign, 100*float64(diff)/float64(prev))
+}
+
+// formatMail formats a plain-text e-mail message
+func formatMail(from, to, subject, body string) string {
+	sb := strings.Builder{}
+	if from != "" {
+		fmt.Fprintf(&sb, "From: %s\r\n", from)
+	}
+	if to != "" {
+		fmt.Fprintf(&sb, "To: %s\r\n", to)
+	}
+	fmt.Fprintf(&sb, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&sb, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&sb, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&sb, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&sb, "Content-Transfer-Encoding: 8bit\r\n")
+	fmt.Fprintf(&sb, "\r\n")
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDigest(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	records := []core.Record{
+		{Project: "test", Start: util.DateTime(2023, 3, 7, 10, 0, 0), End: util.DateTime(2023, 3, 7, 12, 0, 0), Tags: map[string]string{}},
+		{Project: "test", Start: util.DateTime(2023, 3, 14, 10, 0, 0), End: util.DateTime(2023, 3, 14, 13, 0, 0), Note: "+foo", Tags: map[string]string{"foo": ""}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"report", "digest", "--date", "2023-03-15"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+	got := string(outStr)
+
+	assert.Contains(t, got, "# Digest for week 2023-03-13 - 2023-03-19", "Wrong digest title")
+	assert.Contains(t, got, "| test | 03:00 | 02:00 | +01:00 / +50% |", "Wrong project entry")
+	assert.Contains(t, got, "1. +foo: 03:00 (1 records)", "Wrong tag entry")
+	assert.Contains(t, got, "1. Tue 2023-03-14: 03:00", "Wrong day entry")
+}
+
+func TestFormatChange(t *testing.T) {
+	assert.Equal(t, "+01:00 / +50%", formatChange(3*time.Hour, 2*time.Hour))
+	assert.Equal(t, "-01:00 / -50%", formatChange(time.Hour, 2*time.Hour))
+	assert.Equal(t, "+01:00", formatChange(time.Hour, 0))
+}
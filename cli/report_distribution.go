@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+func distributionReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var slot time.Duration
+	var csv bool
+	var perProject bool
+
+	distribution := &cobra.Command{
+		Use:   "distribution",
+		Short: "Distribution of time over weekdays and times of the day",
+		Long: `Distribution of time over weekdays and times of the day
+
+Shows the average time per weekday and time slot, as well as the median start and end of work days,
+and statistics of breaks derived from pauses and gaps between records up to the configured maxBreakDuration.
+Without --start and --end, the time range of all filtered records is used.
+
+Use --per-project for a separate distribution of each top-level project.`,
+		Aliases: []string{"D"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			reporter, err := createReporter(t, options, filters, filters.Start, filters.End)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			bounds := reporter.Bounds()
+			groups := map[string][]core.Record{"": reporter.Records}
+			if perProject {
+				groups = groupByTopLevel(reporter)
+				if len(groups) == 0 {
+					groups[""] = nil
+				}
+			}
+			names := maps.Keys(groups)
+			sort.Strings(names)
+
+			dists := make([]*core.Distribution, len(names))
+			for i, name := range names {
+				dists[i], err = core.NewDistribution(groups[name], bounds.Start, bounds.End, slot, t.Config.MaxBreakDuration)
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+			}
+
+			if csv {
+				out.Print("%s", renderDistributionCsv(names, dists, t.Config.Calendar()))
+				return nil
+			}
+
+			space := []rune(t.Config.EmptyCell)[0]
+			for i, name := range names {
+				if i > 0 {
+					out.Print("\n")
+				}
+				if name != "" {
+					out.Print("%s\n", name)
+				}
+				out.Print("%s", renderDistribution(dists[i], space, t.Config.Calendar()))
+			}
+			return nil
+		},
+	}
+	distribution.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	distribution.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	distribution.Flags().DurationVar(&slot, "resolution", time.Hour, "Duration of time slots. Must divide a day")
+	distribution.Flags().BoolVar(&csv, "csv", false, "Report in CSV format")
+	distribution.Flags().BoolVar(&perProject, "per-project", false, "Separate distributions per top-level project")
+
+	return distribution
+}
+
+// groupByTopLevel groups the records of a reporter by their top-level project
+func groupByTopLevel(r *core.Reporter) map[string][]core.Record {
+	groups := map[string][]core.Record{}
+	for _, rec := range r.Records {
+		node, ok := r.ProjectsTree.Nodes[rec.Project]
+		if !ok {
+			continue
+		}
+		for node.Parent != nil && node.Parent != r.ProjectsTree.Root {
+			node = node.Parent
+		}
+		groups[node.Value.Name] = append(groups[node.Value.Name], rec)
+	}
+	return groups
+}
+
+func renderDistribution(d *core.Distribution, space rune, cal util.Calendar) string {
+	slots := len(d.Time[0])
+	perHour := int(time.Hour / d.Slot)
+	labelStep := 3 * perHour
+	if labelStep == 0 {
+		labelStep = 1
+	}
+
+	header := []rune(strings.Repeat(" ", slots+3))
+	for i := 0; i < slots; i += labelStep {
+		label := fmt.Sprintf("%d", time.Duration(i)*d.Slot/time.Hour)
+		for j, c := range label {
+			if i+j < slots {
+				header[3+i+j] = c
+			}
+		}
+	}
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "%s %6s\n", string(header), "avg")
+	for row := 0; row < 7; row++ {
+		weekday := cal.Weekday(row)
+		fmt.Fprintf(&sb, "%s ", weekday.String()[:2])
+		for slot := 0; slot < slots; slot++ {
+			v := float64(d.Average(weekday, slot)) / float64(d.Slot)
+			block := util.FloatToBlock(v, &space)
+			if d.Time[weekday][slot] > 0 && block == util.FloatToBlock(0, &space) {
+				block = util.BlockRunes[1]
+			}
+			fmt.Fprintf(&sb, "%c", block)
+		}
+		fmt.Fprintf(&sb, " %6s\n", util.FormatDuration(d.DayAverage(weekday), false))
+	}
+
+	w := &d.Workdays
+	fmt.Fprintf(&sb, "\nWork days:    %d\n", w.Days)
+	if w.Days > 0 {
+		fmt.Fprintf(&sb, "Median start: %s\n", util.FormatDuration(w.MedianStart))
+		fmt.Fprintf(&sb, "Median end:   %s\n", util.FormatDuration(w.MedianEnd))
+		fmt.Fprintf(
+			&sb, "Breaks:       %.1f per day, median %s, %s per day\n",
+			float64(w.Breaks)/float64(w.Days), util.FormatDuration(w.MedianBreak),
+			util.FormatDuration(w.TotalBreak/time.Duration(w.Days)),
+		)
+	}
+	return sb.String()
+}
+
+func renderDistributionCsv(names []string, dists []*core.Distribution, cal util.Calendar) string {
+	sb := strings.Builder{}
+	fmt.Fprint(&sb, "project,weekday")
+	for slot := range dists[0].Time[0] {
+		fmt.Fprintf(&sb, ",%s", util.FormatDuration(time.Duration(slot)*dists[0].Slot, true))
+	}
+	fmt.Fprint(&sb, ",total\n")
+
+	for i, d := range dists {
+		for row := 0; row < 7; row++ {
+			weekday := cal.Weekday(row)
+			fmt.Fprintf(&sb, "%s,%s", names[i], weekday.String())
+			for slot := range d.Time[weekday] {
+				fmt.Fprintf(&sb, ",%.1f", d.Average(weekday, slot).Minutes())
+			}
+			fmt.Fprintf(&sb, ",%.1f\n", d.DayAverage(weekday).Minutes())
+		}
+	}
+	return sb.String()
+}
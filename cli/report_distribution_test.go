@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDistribution(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	for _, name := range []string{"p1", "p2"} {
+		project := core.NewProject(name, "", "p", []string{}, 15, 0)
+		err = track.SaveProject(project, false)
+		if err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+	child := core.NewProject("child", "p1", "c", []string{}, 15, 0)
+	err = track.SaveProject(child, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 13, 9, 0, 0), End: util.DateTime(2023, 3, 13, 10, 0, 0)},
+		{Project: "child", Start: util.DateTime(2023, 3, 13, 10, 0, 0), End: util.DateTime(2023, 3, 13, 11, 0, 0)},
+		{Project: "p2", Start: util.DateTime(2023, 3, 14, 9, 0, 0), End: util.DateTime(2023, 3, 14, 10, 0, 0)},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"report", "distribution", "-s", "2023-03-13", "-e", "2023-03-19", "--per-project", "--csv"})
+
+	buffer := bytes.NewBufferString("")
+	out.StdOut = buffer
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	outStr, err := io.ReadAll(buffer)
+	if err != nil {
+		t.Fatal("error reading output")
+	}
+	got := string(outStr)
+
+	assert.Contains(t, got, "project,weekday,00:00,01:00,", "Wrong header")
+	assert.Contains(t, got, "p1,Monday,0.0,0.0,0.0,0.0,0.0,0.0,0.0,0.0,0.0,60.0,60.0,0.0,", "Wrong p1 entry")
+	assert.Contains(t, got, ",120.0\n", "Wrong p1 total")
+	assert.Contains(t, got, "p2,Tuesday,0.0,0.0,0.0,0.0,0.0,0.0,0.0,0.0,0.0,60.0,0.0,", "Wrong p2 entry")
+	assert.NotContains(t, got, "child,", "Child project should be merged into parent")
+}
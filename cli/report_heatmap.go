@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render"
+	"github.com/mlange-42/track/render/heatmap"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func heatmapReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var maxDuration time.Duration
+	var svg bool
+	var svgOptions heatmap.SvgOptions
+
+	heatmapReport := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Calendar heatmap of tracked time per day",
+		Long: `Calendar heatmap of tracked time per day
+
+Shows one column per week and one row per weekday, shaded by the time tracked per day.
+Without --start, shows the last 52 weeks.
+
+Use flag --svg to generate an SVG image:
+
+  track report heatmap --start 2023 --end 2023 --svg > heatmap.svg`,
+		Aliases: []string{"hm"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			if endTime.IsZero() {
+				endTime = util.AddDays(util.ToDate(time.Now()), 1)
+			}
+			if startTime.IsZero() {
+				startTime = util.AddDays(t.Config.Calendar().WeekStart(endTime.Add(-time.Second)), -7*51)
+			}
+			if !startTime.Before(endTime) {
+				return fmt.Errorf("failed to generate report: start date must be before end date")
+			}
+
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			filters = core.NewFilter(filters.Functions, startTime, endTime)
+
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			var renderer render.Renderer
+			if svg {
+				renderer = heatmap.SvgRenderer{
+					Reporter: reporter,
+					Start:    startTime,
+					End:      endTime,
+					Max:      maxDuration,
+					Options:  svgOptions,
+				}
+			} else {
+				renderer = heatmap.TextRenderer{
+					Reporter: reporter,
+					Start:    startTime,
+					End:      endTime,
+					Max:      maxDuration,
+					Space:    &[]rune(t.Config.EmptyCell)[0],
+				}
+			}
+			if err = renderer.Render(out.StdOut); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			return nil
+		},
+	}
+	heatmapReport.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	heatmapReport.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	heatmapReport.Flags().DurationVarP(&maxDuration, "max", "m", 8*time.Hour, "Time per day for full shading. Auto-scale if zero")
+
+	heatmapReport.Flags().BoolVar(&svg, "svg", false, "Generate an SVG image")
+	heatmapReport.Flags().Float64Var(&svgOptions.CellSize, "cell-size", 12, "Size of day cells in SVG output")
+	heatmapReport.Flags().Float64Var(&svgOptions.Gap, "gap", 3, "Gap between day cells in SVG output")
+	heatmapReport.Flags().StringVar(&svgOptions.Color, "color", "#216e39", "Color for full days in SVG output, as #rrggbb")
+
+	return heatmapReport
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/html"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func htmlReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var title string
+
+	htmlReport := &cobra.Command{
+		Use:   "html",
+		Short: "Generates a self-contained HTML report with interactive charts",
+		Long: `Generates a self-contained HTML report with interactive charts
+
+The report contains a treemap of projects, a timeline of time per project,
+statistics of tags and a sortable table of all records.
+It does not require any external assets, and can be shared as a single file.
+
+Write the report to a file like this:
+
+  track report html --start "last month" > report.html`,
+		Aliases: []string{"H"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			renderer := html.Renderer{
+				Reporter: reporter,
+				Title:    title,
+			}
+			if err = renderer.Render(out.StdOut); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			return nil
+		},
+	}
+	htmlReport.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	htmlReport.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	htmlReport.Flags().StringVar(&title, "title", "Track report", "Title of the report")
+
+	return htmlReport
+}
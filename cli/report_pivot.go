@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/table"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+const pivotJSON = "json"
+
+type pivotJSONCell struct {
+	Row   string  `json:"row"`
+	Col   string  `json:"col"`
+	Work  float64 `json:"work"`
+	Pause float64 `json:"pause"`
+}
+
+type pivotJSONTotal struct {
+	Label string  `json:"label"`
+	Work  float64 `json:"work"`
+	Pause float64 `json:"pause"`
+}
+
+type pivotJSONTable struct {
+	Rows      string           `json:"rows"`
+	Cols      string           `json:"cols"`
+	Cells     []pivotJSONCell  `json:"cells"`
+	RowTotals []pivotJSONTotal `json:"rowTotals"`
+	ColTotals []pivotJSONTotal `json:"colTotals"`
+	Work      float64          `json:"work"`
+	Pause     float64          `json:"pause"`
+}
+
+func pivotReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var rows string
+	var cols string
+	var format string
+	var pause bool
+
+	pivotReport := &cobra.Command{
+		Use:   "pivot",
+		Short: "Shows a cross-table of time, grouped by arbitrary keys",
+		Long: fmt.Sprintf(`Shows a cross-table of time, grouped by arbitrary keys
+
+Rows and columns are grouped by one of these keys:
+  %s
+
+Keys "client" and "meta.KEY" use the project fields, inherited from parent projects.
+Key "tag" groups by tag names, "tag:NAME" by the values of a tag.
+Records with several labels for a key, like several tags, are counted for each of them,
+but only once in totals.
+
+Formats are json and all table formats. Durations in JSON are in minutes.`, strings.Join(core.PivotKeys, ", ")),
+		Aliases: []string{"pv"},
+		Args:    util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, key := range []string{rows, cols} {
+				if err := core.CheckPivotKey(key); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+			}
+			if format != pivotJSON {
+				if err := table.CheckFormat(format); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+			}
+
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			pivot, err := reporter.Pivot(rows, cols)
+			if err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
+			if format == pivotJSON {
+				bytes, err := json.MarshalIndent(toPivotJSON(pivot), "", "    ")
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				out.Print("%s\n", bytes)
+				return nil
+			}
+
+			if err := renderTable(pivotTable(pivot, pause), format); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			return nil
+		},
+	}
+
+	pivotReport.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	pivotReport.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	pivotReport.Flags().StringVarP(&rows, "rows", "r", core.PivotProject, "Key for grouping rows")
+	pivotReport.Flags().StringVarP(&cols, "cols", "c", core.PivotMonth, "Key for grouping columns")
+	pivotReport.Flags().StringVarP(&format, "format", "f", table.Text, fmt.Sprintf("Output format (%s|%s)", strings.Join(table.Formats, "|"), pivotJSON))
+	pivotReport.Flags().BoolVar(&pause, "pause", false, "Show pause times instead of work times")
+
+	return pivotReport
+}
+
+// pivotTable creates a table from a pivot table, with totals
+func pivotTable(pivot *core.PivotTable, pause bool) *table.Table {
+	value := func(c *core.PivotCell) string {
+		if c == nil || (c.Work == 0 && c.Pause == 0) {
+			return ""
+		}
+		if pause {
+			return util.FormatDuration(c.Pause)
+		}
+		return util.FormatDuration(c.Work)
+	}
+
+	header := append([]string{pivot.RowKey + " \\ " + pivot.ColKey}, pivot.Cols...)
+	tab := table.New(append(header, "total")...)
+	for i := 1; i < len(tab.AlignRight); i++ {
+		tab.AlignRight[i] = true
+	}
+	for _, row := range pivot.Rows {
+		line := []string{row}
+		for _, col := range pivot.Cols {
+			cell := pivot.Cell(row, col)
+			line = append(line, value(&cell))
+		}
+		tab.Append(append(line, value(pivot.RowTotals[row]))...)
+	}
+	line := []string{"total"}
+	for _, col := range pivot.Cols {
+		line = append(line, value(pivot.ColTotals[col]))
+	}
+	tab.Append(append(line, value(&pivot.Total))...)
+	return tab
+}
+
+func toPivotJSON(pivot *core.PivotTable) pivotJSONTable {
+	result := pivotJSONTable{
+		Rows:      pivot.RowKey,
+		Cols:      pivot.ColKey,
+		Cells:     []pivotJSONCell{},
+		RowTotals: []pivotJSONTotal{},
+		ColTotals: []pivotJSONTotal{},
+		Work:      pivot.Total.Work.Minutes(),
+		Pause:     pivot.Total.Pause.Minutes(),
+	}
+	for _, row := range pivot.Rows {
+		for _, col := range pivot.Cols {
+			cell := pivot.Cell(row, col)
+			if cell.Work == 0 && cell.Pause == 0 {
+				continue
+			}
+			result.Cells = append(result.Cells, pivotJSONCell{
+				Row: row, Col: col, Work: cell.Work.Minutes(), Pause: cell.Pause.Minutes(),
+			})
+		}
+		result.RowTotals = append(result.RowTotals, newPivotJSONTotal(row, pivot.RowTotals[row]))
+	}
+	for _, col := range pivot.Cols {
+		result.ColTotals = append(result.ColTotals, newPivotJSONTotal(col, pivot.ColTotals[col]))
+	}
+	return result
+}
+
+func newPivotJSONTotal(label string, cell *core.PivotCell) pivotJSONTotal {
+	return pivotJSONTotal{Label: label, Work: cell.Work.Minutes(), Pause: cell.Pause.Minutes()}
+}
@@ -2,49 +2,85 @@ package cli
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 	"unicode/utf8"
 
-	"github.com/gookit/color"
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/table"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
 )
 
 func projectsReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var groupBy string
+	var format string
+
 	projects := &cobra.Command{
-		Use:     "projects",
-		Short:   "Shows the project tree with time statistics",
+		Use:   "projects",
+		Short: "Shows the project tree with time statistics",
+		Long: `Shows the project tree with time statistics
+
+With flag --group-by, shows the total time per value of a project field instead of the tree.
+Fields are 'client', or 'meta.KEY' for metadata entries.
+Projects without a value inherit it from their closest ancestor.
+
+Formats other than text show a flat table of projects, with their parent,
+total time including children, and own time.`,
 		Aliases: []string{"p"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := table.CheckFormat(format); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+
 			projects, err := t.LoadAllProjects()
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			filters, err := createFilters(options, projects, false)
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			startTime, endTime, err := parseStartEnd(options)
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
-			reporter, err := core.NewReporter(
-				t, options.projects, filters,
-				options.includeArchived, startTime, endTime,
-			)
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
+			if groupBy != "" {
+				totals, err := reporter.FieldTotals(groupBy)
+				if err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				if format == table.Text {
+					out.Print("%s", formatFieldTotals(totals))
+					return nil
+				}
+				if err := renderTable(fieldTotalsTable(groupBy, totals), format); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				return nil
+			}
+
 			tree, err := t.ToProjectTree(reporter.Projects)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
+			if format != table.Text {
+				if err := renderTable(projectsTable(tree, reporter), format); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
+				}
+				return nil
+			}
 			var active string
 			rec, err := t.OpenRecord()
 			if err != nil {
@@ -63,7 +99,7 @@ func projectsReportCommand(t *core.Track, options *filterOptions) *cobra.Command
 					}
 					var str string
 					if t.Value.Name == active {
-						str = color.BgBlue.Sprintf("%s", name)
+						str = out.Highlight(name)
 					} else {
 						str = name
 					}
@@ -87,6 +123,71 @@ func projectsReportCommand(t *core.Track, options *filterOptions) *cobra.Command
 	}
 	projects.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
 	projects.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	projects.Flags().StringVarP(&groupBy, "group-by", "g", "", "Project field to group by, like 'client' or 'meta.KEY'")
+	addFormatFlag(projects, &format)
 
 	return projects
 }
+
+// projectsTable creates a table of projects in tree order, with total and own time
+func projectsTable(tree *core.ProjectTree, r *core.Reporter) *table.Table {
+	tab := table.New("project", "parent", "total", "own")
+	tab.AlignRight[2], tab.AlignRight[3] = true, true
+
+	var walk func(node *core.ProjectNode)
+	walk = func(node *core.ProjectNode) {
+		parent := ""
+		if node.Parent != nil {
+			parent = node.Parent.Value.Name
+		}
+		name := node.Value.Name
+		tab.Append(name, parent, util.FormatDuration(r.TotalTime[name]), util.FormatDuration(r.ProjectTime[name]))
+
+		children := maps.Keys(node.Children)
+		sort.Strings(children)
+		for _, child := range children {
+			walk(node.Children[child])
+		}
+	}
+	walk(tree.Root)
+	return tab
+}
+
+// fieldTotalsTable creates a table of total times per field value, sorted by time
+func fieldTotalsTable(field string, totals map[string]time.Duration) *table.Table {
+	tab := table.New(field, "total")
+	tab.AlignRight[1] = true
+	for _, v := range sortedFieldValues(totals) {
+		tab.Append(v, util.FormatDuration(totals[v]))
+	}
+	return tab
+}
+
+// sortedFieldValues returns the field values, sorted by descending time
+func sortedFieldValues(totals map[string]time.Duration) []string {
+	values := maps.Keys(totals)
+	sort.Slice(values, func(i, j int) bool {
+		if totals[values[i]] == totals[values[j]] {
+			return values[i] < values[j]
+		}
+		return totals[values[i]] > totals[values[j]]
+	})
+	return values
+}
+
+// formatFieldTotals formats total times per field value, sorted by time
+func formatFieldTotals(totals map[string]time.Duration) string {
+	values := sortedFieldValues(totals)
+
+	width := 16
+	for _, v := range values {
+		if l := utf8.RuneCountInString(v); l > width {
+			width = l
+		}
+	}
+	sb := strings.Builder{}
+	for _, v := range values {
+		fmt.Fprintf(&sb, "%-*s %6s\n", width, v, util.FormatDuration(totals[v], false))
+	}
+	return sb.String()
+}
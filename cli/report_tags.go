@@ -4,108 +4,79 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 	"unicode/utf8"
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/table"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 )
 
-type tagStats struct {
-	Count  int
-	Work   time.Duration
-	Pause  time.Duration
-	Values map[string]*tagValueStats
-}
-
-type tagValueStats struct {
-	Count int
-	Work  time.Duration
-	Pause time.Duration
-}
-
 func tagsReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
+	var tree bool
+	var format string
+
 	tagsReport := &cobra.Command{
-		Use:     "tags",
-		Short:   "Shows tags with time statistics",
+		Use:   "tags",
+		Short: "Shows tags with time statistics",
+		Long: `Shows tags with time statistics
+
+Shows the number of records, and the work and pause time per tag.
+If a single tag is given with --tags, statistics are shown per value.
+
+Use flag --tree to show a tree of tags and their values, with totals.
+Hierarchical values, like in "+area=backend/db", are aggregated to their parents.
+
+Formats other than text show a table. Flag --tree is only supported for text.`,
 		Aliases: []string{"t"},
 		Args:    util.WrappedArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := table.CheckFormat(format); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err.Error())
+			}
+			if tree && format != table.Text {
+				return fmt.Errorf("failed to generate report: flag --tree can only be used with format text")
+			}
+
 			projects, err := t.LoadAllProjects()
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			filters, err := createFilters(options, projects, false)
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			startTime, endTime, err := parseStartEnd(options)
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
-			reporter, err := core.NewReporter(
-				t, options.projects, filters,
-				options.includeArchived, startTime, endTime,
-			)
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			tags := map[string]bool{}
-			for _, tag := range options.tags {
-				k, _ := core.ParseTag(tag)
-				tags[k] = true
+			if tree {
+				out.Print("%s", renderTagTree(reporter, options.tags))
+				return nil
 			}
 
-			valueStats := false
-			if len(tags) == 1 {
-				valueStats = true
-			}
+			valueStats := len(options.tags) == 1
+			allTags := reporter.TagStats(options.tags)
 
-			allTags := map[string]*tagStats{}
-			for _, rec := range reporter.Records {
-				dur := rec.Duration(util.NoTime, util.NoTime)
-				pause := rec.PauseDuration(util.NoTime, util.NoTime)
-				for tag, value := range rec.Tags {
-					if _, ok := tags[tag]; ok || len(tags) == 0 {
-						if _, ok := allTags[tag]; !ok {
-							allTags[tag] = &tagStats{
-								Count: 0,
-								Work:  0,
-								Pause: 0,
-							}
-						}
-						entry := allTags[tag]
-						entry.Work += dur
-						entry.Pause += pause
-						entry.Count++
+			keys := maps.Keys(allTags)
+			sort.Strings(keys)
 
-						if entry.Values == nil {
-							entry.Values = make(map[string]*tagValueStats)
-						}
-						if _, ok := entry.Values[value]; !ok {
-							entry.Values[value] = &tagValueStats{
-								Count: 0,
-								Work:  0,
-								Pause: 0,
-							}
-						}
-						values := entry.Values[value]
-						values.Work += dur
-						values.Pause += pause
-						values.Count++
-					}
+			if format != table.Text {
+				if err := renderTable(tagsTable(allTags, keys, valueStats), format); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err.Error())
 				}
+				return nil
 			}
 
-			keys := maps.Keys(allTags)
-			sort.Strings(keys)
-
 			for _, tag := range keys {
 				stats := allTags[tag]
 				fillLen := 15 - utf8.RuneCountInString(tag)
@@ -170,6 +141,75 @@ func tagsReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	}
 	tagsReport.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
 	tagsReport.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	tagsReport.Flags().BoolVar(&tree, "tree", false, "Show a tree of tags and hierarchical values, with totals")
+	addFormatFlag(tagsReport, &format)
 
 	return tagsReport
 }
+
+// tagsTable creates a table of tag statistics, or of value statistics for a single tag
+func tagsTable(allTags map[string]*core.TagStats, keys []string, valueStats bool) *table.Table {
+	if !valueStats {
+		tab := table.New("tag", "count", "work", "pause", "values")
+		tab.AlignRight[1], tab.AlignRight[2], tab.AlignRight[3] = true, true, true
+		for _, tag := range keys {
+			stats := allTags[tag]
+			values := maps.Keys(stats.Values)
+			sort.Strings(values)
+			if len(values) == 1 && values[0] == "" {
+				values = nil
+			}
+			tab.Append(
+				tag, fmt.Sprint(stats.Count),
+				util.FormatDuration(stats.Work), util.FormatDuration(stats.Pause),
+				strings.Join(values, " "),
+			)
+		}
+		return tab
+	}
+
+	tab := table.New("tag", "value", "count", "work", "pause")
+	tab.AlignRight[2], tab.AlignRight[3], tab.AlignRight[4] = true, true, true
+	for _, tag := range keys {
+		values := maps.Keys(allTags[tag].Values)
+		sort.Strings(values)
+		for _, v := range values {
+			stats := allTags[tag].Values[v]
+			tab.Append(
+				tag, v, fmt.Sprint(stats.Count),
+				util.FormatDuration(stats.Work), util.FormatDuration(stats.Pause),
+			)
+		}
+	}
+	return tab
+}
+
+func renderTagTree(r *core.Reporter, tags []string) string {
+	tree, stats := r.TagTree(tags)
+
+	formatter := util.NewTreeFormatter(
+		func(t *core.TagTreeNode, indent int) string {
+			fillLen := 16 - (indent + utf8.RuneCountInString(t.Value.Label))
+			name := t.Value.Label
+			if fillLen < 0 {
+				nameRunes := []rune(name)
+				name = string(nameRunes[:len(nameRunes)+fillLen-1]) + "."
+			}
+			if t.Parent == nil {
+				return name
+			}
+			if fillLen > 0 {
+				name += strings.Repeat(" ", fillLen)
+			}
+			st := stats[t.Value.Name]
+			return fmt.Sprintf(
+				"%s %3d  %6s (%5s)", name,
+				st.Count,
+				util.FormatDuration(st.Work, false),
+				util.FormatDuration(st.Pause, false),
+			)
+		},
+		2,
+	)
+	return formatter.FormatTree(tree)
+}
@@ -9,34 +9,79 @@ import (
 
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/render/chart"
+	"github.com/mlange-42/track/render/table"
 	"github.com/mlange-42/track/util"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 )
 
-var timelineModes = map[string]func(*core.Reporter, bool, bool) string{
-	"days":   timelineDays,
-	"weeks":  timelineWeeks,
-	"months": timelineMonths,
-	"d":      timelineDays,
-	"w":      timelineWeeks,
-	"m":      timelineMonths,
+// timelineData holds the total time per time bin, overall and per project
+type timelineData struct {
+	dates         []time.Time
+	values        []time.Duration
+	projectValues map[string][]time.Duration
+	// time per box for the bar chart
+	perBox time.Duration
+	// date format for labels in charts
+	labelFormat string
+}
+
+var timelineModes = map[string]func(*core.Reporter) timelineData{
+	"days":    timelineDays,
+	"weeks":   timelineWeeks,
+	"months":  timelineMonths,
+	"periods": timelineCustomPeriods,
+	"d":       timelineDays,
+	"w":       timelineWeeks,
+	"m":       timelineMonths,
+	"p":       timelineCustomPeriods,
 }
 
 func timelineReportCommand(t *core.Track, options *filterOptions) *cobra.Command {
 	var csv bool
-	var table bool
+	var perProject bool
+	var format string
+	var image imageOptions
 
 	timeline := &cobra.Command{
-		Use:     "timeline (days|weeks|months)",
-		Short:   "Timeline reports of time tracking",
+		Use:   "timeline (days|weeks|months|periods)",
+		Short: "Timeline reports of time tracking",
+		Long: `Timeline reports of time tracking
+
+Mode "periods" uses the custom periods defined in the config, like fiscal months.
+Weeks start at the day configured in the config (default: monday).
+
+With format text, shows a bar chart. All other formats show a table,
+with one column per project if flag --table is given.
+Flag --table reports in CSV format, unless another format is given with --format.
+Use --format text --table for a text table.
+
+Use flags --svg or --png for a chart of stacked bars per project, in project colors:
+
+  track report timeline weeks --start 2023-01 --svg > timeline.svg`,
 		Aliases: []string{"l"},
 		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			mode := args[0]
 
-			if table && !csv {
-				return fmt.Errorf("failed to generate report: flag --table can only be used together with --csv")
+			if csv {
+				if cmd.Flags().Changed("format") && format != table.Csv {
+					return fmt.Errorf("failed to generate report: flags --csv and --format are mutually exclusive")
+				}
+				format = table.Csv
+			}
+			if perProject && !cmd.Flags().Changed("format") {
+				format = table.Csv
+			}
+			if err := table.CheckFormat(format); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err)
+			}
+			if err := image.check(); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err)
+			}
+			if image.enabled() && (format != table.Text || perProject) {
+				return fmt.Errorf("failed to generate report: flags --svg and --png can't be used with tables")
 			}
 
 			projects, err := t.LoadAllProjects()
@@ -44,7 +89,7 @@ func timelineReportCommand(t *core.Track, options *filterOptions) *cobra.Command
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
 
-			filters, err := createFilters(options, projects, false)
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
@@ -54,87 +99,93 @@ func timelineReportCommand(t *core.Track, options *filterOptions) *cobra.Command
 				return fmt.Errorf("failed to generate report: invalid timeline argument '%s'", mode)
 			}
 
-			startTime, endTime, err := parseStartEnd(options)
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
-			reporter, err := core.NewReporter(
-				t, options.projects, filters,
-				options.includeArchived, startTime, endTime,
-			)
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err)
 			}
 
-			out.Print(timelineFunc(reporter, csv, table))
+			data := timelineFunc(reporter)
+			if image.enabled() {
+				labels := make([]string, len(data.dates))
+				for i, d := range data.dates {
+					labels[i] = d.Format(data.labelFormat)
+				}
+				title := fmt.Sprintf("Timeline %s", mode)
+				if len(data.dates) > 0 {
+					title = fmt.Sprintf("%s, %s - %s", title, data.dates[0].Format(util.DateFormat), reporter.TimeRange.End.Format(util.DateFormat))
+				}
+				if err := renderImage(chart.Timeline(title, labels, data.projectValues, reporter.AllProjects), &image); err != nil {
+					return fmt.Errorf("failed to generate report: %s", err)
+				}
+				return nil
+			}
+			if format == table.Text && !perProject {
+				out.Print(renderTimeline(data.dates, data.values, data.perBox))
+				return nil
+			}
+			if err := renderTable(timelineTable(&data, perProject), format); err != nil {
+				return fmt.Errorf("failed to generate report: %s", err)
+			}
 			return nil
 		},
 	}
 	timeline.Flags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
 	timeline.Flags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
 
-	timeline.Flags().BoolVar(&csv, "csv", false, "Report in CSV format")
-	timeline.Flags().BoolVar(&table, "table", false, "For report in CSV format, reports one column per project")
+	timeline.Flags().BoolVar(&csv, "csv", false, "Report in CSV format. Same as --format csv")
+	timeline.Flags().BoolVar(&perProject, "table", false, "Report as a table with one column per project")
+	addFormatFlag(timeline, &format)
+	addImageFlags(timeline, &image)
 
 	return timeline
 }
 
-func timelineDays(r *core.Reporter, csv bool, table bool) string {
-	startDate := util.ToDate(r.TimeRange.Start)
-	if table {
-		return timelineTable(r, startDate, time.Hour*24)
-	}
-	return timeline(r, startDate, time.Hour*24, 30*time.Minute, csv)
+func timelineDays(r *core.Reporter) timelineData {
+	data := timelineValues(r, util.ToDate(r.TimeRange.Start), time.Hour*24, 30*time.Minute)
+	data.labelFormat = "01-02"
+	return data
 }
 
-func timelineWeeks(r *core.Reporter, csv bool, table bool) string {
-	startDate := util.ToDate(r.TimeRange.Start)
-	weekDay := (int(startDate.Weekday()) + 6) % 7
-	startDate = startDate.Add(time.Duration(-weekDay * 24 * int(time.Hour)))
-	if table {
-		return timelineTable(r, startDate, time.Hour*24*7)
-	}
-	return timeline(r, startDate, time.Hour*24*7, 2*time.Hour, csv)
+func timelineWeeks(r *core.Reporter) timelineData {
+	data := timelineValues(r, r.Track.Config.Calendar().WeekStart(r.TimeRange.Start), time.Hour*24*7, 2*time.Hour)
+	data.labelFormat = "01-02"
+	return data
 }
 
-func timelineMonths(r *core.Reporter, csv bool, table bool) string {
-	y1, m1, _ := r.TimeRange.Start.Date()
-	y2, m2, _ := r.TimeRange.End.Date()
-	numBins := (y2-y1)*12 + int(m2) - int(m1) + 1
+func timelineMonths(r *core.Reporter) timelineData {
+	data := timelinePeriods(r, util.Period{Months: 1, StartDay: 1, StartMonth: 1})
+	data.labelFormat = "2006-01"
+	return data
+}
 
-	dates := make([]time.Time, numBins)
-	year, month := y1, m1
-	for i := range dates {
-		dates[i] = util.Date(year, month, 1)
-		month++
-		if month > 12 {
-			year++
-			month = 1
-		}
-	}
+func timelineCustomPeriods(r *core.Reporter) timelineData {
+	data := timelinePeriods(r, r.Track.Config.Calendar().Period)
+	data.labelFormat = util.DateFormat
+	return data
+}
 
-	values := make([]time.Duration, numBins)
-	projectValues := make(map[string][]time.Duration)
-	for p := range r.Projects {
-		projectValues[p] = make([]time.Duration, numBins)
+func timelinePeriods(r *core.Reporter, period util.Period) timelineData {
+	dates := []time.Time{}
+	for date := period.Start(r.TimeRange.Start); !date.After(r.TimeRange.End); date = period.Next(date) {
+		dates = append(dates, date)
 	}
+	numBins := len(dates)
+
+	data := newTimelineData(r, dates, 8*time.Hour)
 	for _, rec := range r.Records {
-		y2, m2, _ := rec.Start.Date()
-		d := (y2-y1)*12 + int(m2) - int(m1)
-		dur := rec.Duration(r.TimeRange.Start, r.TimeRange.End)
-		values[d] += dur
-		projectValues[rec.Project][d] += dur
-	}
-	if table {
-		return renderTimelineTable(dates, values, projectValues)
+		d := sort.Search(numBins, func(i int) bool { return dates[i].After(rec.Start) }) - 1
+		data.add(&rec, d, r.TimeRange)
 	}
-	if csv {
-		return renderTimelineCsv(dates, values)
-	}
-	return renderTimeline(dates, values, 8*time.Hour)
+	return data
 }
 
-func timeline(r *core.Reporter, startDate time.Time, delta time.Duration, perBox time.Duration, csv bool) string {
+// timelineValues calculates the total time per time bin of the given size.
+// Records are assigned to the bin in which they start.
+func timelineValues(r *core.Reporter, startDate time.Time, delta time.Duration, perBox time.Duration) timelineData {
 	minDate := startDate
 	maxDate := util.ToDate(r.TimeRange.End.Add(delta))
 	numBins := int(maxDate.Sub(minDate).Hours() / delta.Hours())
@@ -146,44 +197,35 @@ func timeline(r *core.Reporter, startDate time.Time, delta time.Duration, perBox
 		currDate = currDate.Add(delta)
 	}
 
-	values := make([]time.Duration, numBins)
+	data := newTimelineData(r, dates, perBox)
 	for _, rec := range r.Records {
 		// TODO: split if over increment
 		d := int(rec.Start.Sub(minDate).Hours() / delta.Hours())
-		values[d] = values[d] + rec.Duration(r.TimeRange.Start, r.TimeRange.End)
-	}
-	if csv {
-		return renderTimelineCsv(dates, values)
+		data.add(&rec, d, r.TimeRange)
 	}
-	return renderTimeline(dates, values, perBox)
+	return data
 }
 
-func timelineTable(r *core.Reporter, startDate time.Time, delta time.Duration) string {
-	minDate := startDate
-	maxDate := util.ToDate(r.TimeRange.End.Add(delta))
-	numBins := int(maxDate.Sub(minDate).Hours() / delta.Hours())
-
-	dates := make([]time.Time, numBins)
-	currDate := minDate
-	for i := range dates {
-		dates[i] = currDate
-		currDate = currDate.Add(delta)
-	}
-
-	values := make([]time.Duration, numBins)
+func newTimelineData(r *core.Reporter, dates []time.Time, perBox time.Duration) timelineData {
 	projectValues := make(map[string][]time.Duration)
 	for p := range r.Projects {
-		projectValues[p] = make([]time.Duration, numBins)
+		projectValues[p] = make([]time.Duration, len(dates))
+	}
+	return timelineData{
+		dates:         dates,
+		values:        make([]time.Duration, len(dates)),
+		projectValues: projectValues,
+		perBox:        perBox,
 	}
+}
 
-	for _, rec := range r.Records {
-		// TODO: split if over increment
-		d := int(rec.Start.Sub(minDate).Hours() / delta.Hours())
-		dur := rec.Duration(r.TimeRange.Start, r.TimeRange.End)
-		values[d] += dur
-		projectValues[rec.Project][d] += dur
+// add adds the time of a record to the given bin
+func (d *timelineData) add(rec *core.Record, bin int, tr core.TimeRange) {
+	dur := rec.Duration(tr.Start, tr.End)
+	d.values[bin] += dur
+	if values, ok := d.projectValues[rec.Project]; ok {
+		values[bin] += dur
 	}
-	return renderTimelineTable(dates, values, projectValues)
 }
 
 func renderTimeline(dates []time.Time, values []time.Duration, perBox time.Duration) string {
@@ -209,36 +251,29 @@ func renderTimeline(dates []time.Time, values []time.Duration, perBox time.Durat
 	return sb.String()
 }
 
-func renderTimelineCsv(dates []time.Time, values []time.Duration) string {
-	sb := strings.Builder{}
-
-	fmt.Fprintf(&sb, "date,weekday,duration\n")
-	for i := range dates {
-		d := dates[i]
-		v := values[i]
-		fmt.Fprintf(&sb, "%s,%s,%s\n", d.Format(util.DateFormat), d.Weekday().String()[:2], util.FormatDuration(v))
+// timelineTable creates a table of the time per bin, optionally with one column per project
+func timelineTable(data *timelineData, perProject bool) *table.Table {
+	projects := []string{}
+	if perProject {
+		projects = maps.Keys(data.projectValues)
+		sort.Strings(projects)
 	}
 
-	return sb.String()
-}
-
-func renderTimelineTable(dates []time.Time, values []time.Duration, projectValues map[string][]time.Duration) string {
-	sb := strings.Builder{}
-
-	projects := maps.Keys(projectValues)
-	sort.Strings(projects)
+	header := []string{"date", "weekday", "duration"}
+	if perProject {
+		header[2] = "total"
+	}
+	tab := table.New(append(header, projects...)...)
+	for i := range tab.AlignRight {
+		tab.AlignRight[i] = i >= 2
+	}
 
-	fmt.Fprintf(&sb, "date,weekday,total,%s\n", strings.Join(projects, ","))
-	for i := range dates {
-		d := dates[i]
-		v := values[i]
-		fmt.Fprintf(&sb, "%s,%s,%s", d.Format(util.DateFormat), d.Weekday().String()[:2], util.FormatDuration(v))
+	for i, d := range data.dates {
+		row := []string{d.Format(util.DateFormat), d.Weekday().String()[:2], util.FormatDuration(data.values[i])}
 		for _, p := range projects {
-			vp := projectValues[p][i]
-			fmt.Fprintf(&sb, ",%s", util.FormatDuration(vp))
+			row = append(row, util.FormatDuration(data.projectValues[p][i]))
 		}
-		fmt.Fprintf(&sb, "\n")
+		tab.Append(row...)
 	}
-
-	return sb.String()
+	return tab
 }
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportTimelineFormats(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	for _, name := range []string{"p1", "p_2"} {
+		project := core.NewProject(name, "", "p", []string{}, 15, 0)
+		err = track.SaveProject(project, false)
+		if err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 13, 9, 0, 0), End: util.DateTime(2023, 3, 13, 10, 0, 0), Tags: map[string]string{}},
+		{Project: "p_2", Start: util.DateTime(2023, 3, 14, 9, 0, 0), End: util.DateTime(2023, 3, 14, 11, 0, 0), Tags: map[string]string{}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := RootCommand(track, "")
+		cmd.SetArgs(append([]string{"report", "timeline", "days", "--start", "2023-03-13", "--end", "2023-03-14"}, args...))
+		buffer := bytes.NewBufferString("")
+		out.StdOut = buffer
+		err := cmd.Execute()
+		return buffer.String(), err
+	}
+
+	got, err := run("--csv")
+	assert.Nil(t, err)
+	assert.Equal(t, "date,weekday,duration\n2023-03-13,Mo,01:00\n2023-03-14,Tu,02:00\n", got, "Wrong CSV output")
+
+	got, err = run("--table")
+	assert.Nil(t, err)
+	assert.Equal(t, "date,weekday,total,p1,p_2\n2023-03-13,Mo,01:00,01:00,00:00\n2023-03-14,Tu,02:00,00:00,02:00\n", got, "Flag --table should default to CSV")
+
+	got, err = run("--format", "text", "--table")
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"date        weekday  total     p1    p_2\n"+
+			"2023-03-13  Mo       01:00  01:00  00:00\n"+
+			"2023-03-14  Tu       02:00  00:00  02:00\n",
+		got, "Wrong text table")
+
+	got, err = run("--format", "markdown", "--table")
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"| date       | weekday | total |    p1 |   p_2 |\n"+
+			"| ---------- | ------- | ----: | ----: | ----: |\n"+
+			"| 2023-03-13 | Mo      | 01:00 | 01:00 | 00:00 |\n"+
+			"| 2023-03-14 | Tu      | 02:00 | 00:00 | 02:00 |\n",
+		got, "Wrong Markdown output")
+
+	got, err = run("--format", "latex", "--table")
+	assert.Nil(t, err)
+	assert.Contains(t, got, "\\begin{tabular}{llrrr}\n", "Wrong LaTeX column spec")
+	assert.Contains(t, got, "date & weekday & total & p1 & p\\_2 \\\\\n", "Wrong LaTeX header")
+
+	got, err = run("--format", "asciidoc")
+	assert.Nil(t, err)
+	assert.Contains(t, got, "|date |weekday |duration\n", "Wrong AsciiDoc header")
+
+	_, err = run("--format", "html")
+	assert.NotNil(t, err, "Expected error for unknown format")
+	_, err = run("--format", "markdown", "--csv")
+	assert.NotNil(t, err, "Expected error for conflicting flags")
+
+	got, err = run("--svg")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(got, "<svg "), "Wrong SVG output")
+	assert.Contains(t, got, "<title>03-14 p_2: 02:00</title>", "Missing bar in SVG output")
+
+	got, err = run("--png", "--scale", "1")
+	assert.Nil(t, err)
+	img, err := png.Decode(strings.NewReader(got))
+	assert.Nil(t, err)
+	assert.Greater(t, img.Bounds().Dx(), 0, "Empty PNG output")
+
+	_, err = run("--svg", "--png")
+	assert.NotNil(t, err, "Expected error for conflicting flags")
+	_, err = run("--svg", "--csv")
+	assert.NotNil(t, err, "Expected error for conflicting flags")
+}
@@ -26,19 +26,16 @@ func treemapReportCommand(t *core.Track, options *filterOptions) *cobra.Command
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			filters, err := createFilters(options, projects, false)
+			filters, err := createFilters(options, projects, false, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
 
-			startTime, endTime, err := parseStartEnd(options)
+			startTime, endTime, err := parseStartEnd(options, t.Config.Calendar())
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
-			reporter, err := core.NewReporter(
-				t, options.projects, filters,
-				options.includeArchived, startTime, endTime,
-			)
+			reporter, err := createReporter(t, options, filters, startTime, endTime)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %s", err.Error())
 			}
@@ -117,8 +117,8 @@ func resumeLastRecord(t *core.Track, last *core.Record, args []string, atTime st
 	if err != nil {
 		return 0, err
 	}
-	if proj.Archived {
-		return 0, fmt.Errorf("project '%s' is archived", proj.Name)
+	if err := t.CheckArchived(proj.Name); err != nil {
+		return 0, err
 	}
 
 	now := time.Now()
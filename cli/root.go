@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"strings"
+
 	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
 	"github.com/spf13/cobra"
 )
 
 // RootCommand sets up the CLI
 func RootCommand(t *core.Track, version string) *cobra.Command {
+	var colorMode string
+
 	root := &cobra.Command{
 		Use:   "track",
 		Short: "Track is a time tracking command line tool",
@@ -36,27 +41,40 @@ Subcommands
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			return nil
+			if err := out.SetColorMode(colorMode); err != nil {
+				return err
+			}
+			return out.SetTheme(t.Config.Theme)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			_ = cmd.Help()
 		},
 	}
 
+	root.PersistentFlags().StringVar(&colorMode, "color-mode", out.ColorAuto, "Colored output: "+strings.Join(out.ColorModes, ", ")+". Mode auto respects NO_COLOR")
+
 	root.AddCommand(statusCommand(t))
 	root.AddCommand(listCommand(t))
+	root.AddCommand(showCommand(t))
 	root.AddCommand(createCommand(t))
 	root.AddCommand(startCommand(t))
 	root.AddCommand(stopCommand(t))
+	root.AddCommand(addCommand(t))
 	root.AddCommand(resumeCommand(t))
 	root.AddCommand(switchCommand(t))
 	root.AddCommand(pauseCommand(t))
 	root.AddCommand(reportCommand(t))
 	root.AddCommand(editCommand(t))
 	root.AddCommand(deleteCommand(t))
+	root.AddCommand(renameCommand(t))
+	root.AddCommand(archiveCommand(t))
+	root.AddCommand(unarchiveCommand(t))
+	root.AddCommand(restoreCommand(t))
+	root.AddCommand(emptyTrashCommand(t))
 	root.AddCommand(exportCommand(t))
 	root.AddCommand(workspaceCommand(t))
 	root.AddCommand(moveCommand(t))
+	root.AddCommand(tagCommand(t))
 
 	root.Long += "\n\n" + formatCmdTree(root)
 
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorFlag(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+	defer out.SetColorMode(out.ColorAuto)
+
+	err = track.SaveProject(core.NewProject("p1", "", "p", []string{}, 15, 0), false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := RootCommand(track, "")
+		cmd.SetArgs(args)
+		buffer := bytes.NewBufferString("")
+		out.StdOut = buffer
+		err := cmd.Execute()
+		return buffer.String(), err
+	}
+
+	for _, mode := range []string{"never", "always"} {
+		commands := [][]string{
+			{"create", "project", "p_" + mode},
+			{"report", "treemap"},
+			{"report", "heatmap", "--start", "2023-03-01", "--end", "2023-03-31"},
+		}
+		for _, args := range commands {
+			got, err := run(append(args, "--color-mode", mode)...)
+			assert.Nil(t, err, "Unexpected error for %v", args)
+			switch args[1] {
+			case "treemap":
+				assert.Contains(t, got, "<svg", "Expected SVG output")
+			case "heatmap":
+				assert.Contains(t, got, "2023-03-01 - 2023-03-31", "Expected text output")
+			default:
+				assert.Equal(t, mode == "always", strings.Contains(got, "\x1b["), "Wrong color mode for %v %s", args, mode)
+			}
+		}
+	}
+
+	project, err := track.LoadProject("p_never")
+	assert.Nil(t, err)
+	assert.Equal(t, core.NewProject("p_never", "", "p", []string{}, 15, 0), project, "Color mode should not change the project color")
+
+	_, err = run("create", "project", "p_local", "--color", "2", "--color-mode", "never")
+	assert.Nil(t, err)
+	project, err = track.LoadProject("p_local")
+	assert.Nil(t, err)
+	assert.Equal(t, core.NewProject("p_local", "", "p", []string{}, 15, 2), project, "Local flag --color should set the project color")
+
+	_, err = run("report", "treemap", "--color", "none", "--color-mode", "never")
+	assert.Nil(t, err, "Local flag --color should not conflict with --color-mode")
+
+	_, err = run("report", "treemap", "--color-mode", "sometimes")
+	assert.NotNil(t, err, "Expected error for invalid color mode")
+}
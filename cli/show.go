@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+func showCommand(t *core.Track) *cobra.Command {
+	show := &cobra.Command{
+		Use:   "show",
+		Short: "Show details of a resource",
+		Long:  "Show details of a resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	show.AddCommand(showProjectCommand(t))
+
+	show.Long += "\n\n" + formatCmdTree(show)
+	return show
+}
+
+func showProjectCommand(t *core.Track) *cobra.Command {
+	var topTags int
+
+	showProject := &cobra.Command{
+		Use:   "project PROJECT",
+		Short: "Show the properties, metadata and lifetime statistics of a project",
+		Long: `Show the properties, metadata and lifetime statistics of a project
+
+Statistics include the records of all descendants of the project.
+Inherited values of the client are marked with an asterisk (*).`,
+		Aliases: []string{"p"},
+		Args:    util.WrappedArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			projects, err := t.LoadAllProjects()
+			if err != nil {
+				return fmt.Errorf("failed to show project: %s", err)
+			}
+			project, ok := projects[name]
+			if !ok {
+				return fmt.Errorf("failed to show project: no project named '%s'", name)
+			}
+
+			reporter, err := core.NewReporter(
+				t, []string{name}, core.NewFilter([]core.FilterFunction{}, util.NoTime, util.NoTime),
+				true, util.NoTime, util.NoTime,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to show project: %s", err)
+			}
+			stats := core.NewProjectStats(reporter.Records, topTags)
+
+			out.Print("%s\n", formatProject(&project, reporter.ProjectsTree, &stats))
+			return nil
+		},
+	}
+	showProject.Flags().IntVarP(&topTags, "tags", "t", 5, "Number of top tags to show")
+
+	return showProject
+}
+
+// formatProject formats the properties, metadata and statistics of a project
+func formatProject(project *core.Project, tree *core.ProjectTree, stats *core.ProjectStats) string {
+	rows := []util.Pair[string, string]{
+		util.NewPair("Project", fmt.Sprintf("%s %s", project.Name, project.Render.Sprintf(" %s ", project.Symbol))),
+	}
+	add := func(key, value string) {
+		if value != "" {
+			rows = append(rows, util.NewPair(key, value))
+		}
+	}
+
+	add("Parent", project.Parent)
+	if project.Archived {
+		add("Archived", "yes")
+	}
+	add("Description", project.Description)
+	if project.Client != "" {
+		add("Client", project.Client)
+	} else if client := core.ProjectFieldFor(project.Name, core.FieldClient, tree); client != "" {
+		add("Client", client+" *")
+	}
+	add("Required tags", strings.Join(project.RequiredTags, ", "))
+	add("Issues", project.Links.Issues)
+	add("Repository", project.Links.Repository)
+
+	keys := maps.Keys(project.Metadata)
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(core.FieldMetadataPrefix+key, project.Metadata[key])
+	}
+
+	add("Records", fmt.Sprint(stats.Records))
+	if stats.Records > 0 {
+		add("First record", stats.First.Format(util.DateTimeFormat))
+		add("Last record", stats.Last.Format(util.DateTimeFormat))
+		add("Total time", util.FormatDuration(stats.Total, false))
+		tags := make([]string, len(stats.TopTags))
+		for i, tag := range stats.TopTags {
+			tags[i] = fmt.Sprintf("%s (%s)", tag.Key, util.FormatDuration(tag.Value, false))
+		}
+		add("Top tags", strings.Join(tags, ", "))
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row.Key) > width {
+			width = len(row.Key)
+		}
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = fmt.Sprintf("%-*s  %s", width, row.Key, row.Value)
+	}
+	return strings.Join(lines, "\n")
+}
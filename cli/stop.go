@@ -57,7 +57,7 @@ func stopCommand(t *core.Track) *cobra.Command {
 			}
 
 			out.Print("\n")
-			err = t.DeleteRecord(record)
+			err = t.DeleteRecord(record, "deleted on stop")
 			if err != nil {
 				return fmt.Errorf("failed to delete record: %s", err)
 			}
@@ -24,8 +24,9 @@ func switchCommand(t *core.Track) *cobra.Command {
 
 Everything after the project name is considered a note for the record.
 Notes can contain tags, denoted by the prefix "%s", like "%stag"`, core.TagPrefix, core.TagPrefix),
-		Aliases: []string{"sw"},
-		Args:    util.WrappedArgs(cobra.MinimumNArgs(1)),
+		Aliases:           []string{"sw"},
+		Args:              util.WrappedArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: completeProjectAndTags(t),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			project := args[0]
 
@@ -41,8 +42,8 @@ Notes can contain tags, denoted by the prefix "%s", like "%stag"`, core.TagPrefi
 			if err != nil {
 				return fmt.Errorf("failed to start record: %s", err)
 			}
-			if proj.Archived {
-				return fmt.Errorf("failed to start record: project '%s' is archived", proj.Name)
+			if err := t.CheckArchived(proj.Name); err != nil {
+				return fmt.Errorf("failed to start record: %s", err)
 			}
 
 			var startStopTime time.Time
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+type tagOptions struct {
+	filterOptions
+	where  []string
+	dryRun bool
+}
+
+func tagCommand(t *core.Track) *cobra.Command {
+	options := tagOptions{}
+
+	tag := &cobra.Command{
+		Use:   "tag",
+		Short: "Rename, set or remove tags across records",
+		Long: `Rename, set or remove tags across records
+
+Tags are changed in the notes of all records matching the filters.
+Without filters, all records of the current workspace are changed.
+Use flag --dry to preview the changes.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	tag.PersistentFlags().StringSliceVarP(&options.projects, "projects", "p", []string{}, "Projects to include (comma-separated). All projects if not specified")
+	tag.PersistentFlags().StringSliceVarP(&options.where, "where", "w", []string{}, "Tags the records must have (comma-separated), like 'client=acme'. Includes records with all of the given tags")
+	tag.PersistentFlags().StringVarP(&options.start, "start", "s", "", "Start date (start at 00:00)")
+	tag.PersistentFlags().StringVarP(&options.end, "end", "e", "", "End date (inclusive: end at 24:00)")
+	tag.PersistentFlags().BoolVarP(&options.includeArchived, "archived", "a", false, "Include records from archived projects")
+	tag.PersistentFlags().BoolVar(&options.dryRun, "dry", false, "Dry run: only show what would be changed")
+
+	tag.AddCommand(renameTagCommand(t, &options))
+	tag.AddCommand(setTagCommand(t, &options))
+	tag.AddCommand(removeTagCommand(t, &options))
+
+	return tag
+}
+
+func renameTagCommand(t *core.Track, options *tagOptions) *cobra.Command {
+	rename := &cobra.Command{
+		Use:   "rename OLD NEW",
+		Short: "Rename a tag, keeping its values",
+		Long: `Rename a tag, keeping its values
+
+Example:
+
+  track tag rename mtg meeting`,
+		Args: util.WrappedArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldName := strings.TrimPrefix(args[0], core.TagPrefix)
+			newName := strings.TrimPrefix(args[1], core.TagPrefix)
+			if err := checkTagName(oldName); err != nil {
+				return fmt.Errorf("failed to rename tag: %s", err)
+			}
+			if err := checkTagName(newName); err != nil {
+				return fmt.Errorf("failed to rename tag: %s", err)
+			}
+
+			return updateTags(t, options, []string{oldName}, func(note string) string {
+				return core.RenameTag(note, oldName, newName)
+			})
+		},
+	}
+
+	return rename
+}
+
+func setTagCommand(t *core.Track, options *tagOptions) *cobra.Command {
+	set := &cobra.Command{
+		Use:   "set TAG...",
+		Short: "Set tags and their values",
+		Long: `Set tags and their values
+
+Tags that are already present get the new value, other tags are appended to the note.
+
+Example:
+
+  track tag set --where client=acme +billable +rate=90`,
+		Args: util.WrappedArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags := make([]util.Pair[string, string], len(args))
+			for i, arg := range args {
+				name, value := core.ParseTag(strings.TrimPrefix(arg, core.TagPrefix))
+				if err := checkTagName(name); err != nil {
+					return fmt.Errorf("failed to set tags: %s", err)
+				}
+				tags[i] = util.NewPair(name, value)
+			}
+
+			return updateTags(t, options, nil, func(note string) string {
+				for _, tag := range tags {
+					note = core.SetTag(note, tag.Key, tag.Value)
+				}
+				return note
+			})
+		},
+	}
+
+	return set
+}
+
+func removeTagCommand(t *core.Track, options *tagOptions) *cobra.Command {
+	remove := &cobra.Command{
+		Use:   "remove TAG...",
+		Short: "Remove tags",
+		Long: `Remove tags
+
+Removes the given tags with any value from the notes of the records.
+
+Example:
+
+  track tag remove obsolete --start 2023-01-01`,
+		Aliases: []string{"rm"},
+		Args:    util.WrappedArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := make([]string, len(args))
+			for i, arg := range args {
+				names[i] = strings.TrimPrefix(arg, core.TagPrefix)
+				if err := checkTagName(names[i]); err != nil {
+					return fmt.Errorf("failed to remove tags: %s", err)
+				}
+			}
+
+			return updateTags(t, options, names, func(note string) string {
+				for _, name := range names {
+					note = core.RemoveTag(note, name)
+				}
+				return note
+			})
+		},
+	}
+
+	return remove
+}
+
+// updateTags applies a note transformation to all records matching the options, and prints a summary.
+// If anyOf is not empty, only records with any of these tags are considered.
+func updateTags(t *core.Track, options *tagOptions, anyOf []string, fn func(note string) string) error {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return fmt.Errorf("failed to change tags: %s", err)
+	}
+	// Tags of loaded records have aliases resolved, so the filters must use resolved names too
+	aliases := t.Config.TagAliases
+	options.tags = make([]string, len(anyOf))
+	for i, name := range anyOf {
+		options.tags[i] = core.ResolveTagAlias(name, aliases)
+	}
+	filters, err := createFilters(&options.filterOptions, projects, true, t.Config.Calendar())
+	if err != nil {
+		return fmt.Errorf("failed to change tags: %s", err)
+	}
+	if len(options.where) > 0 {
+		where := make([]util.Pair[string, string], len(options.where))
+		for i, tag := range options.where {
+			k, v := core.ParseTag(strings.TrimPrefix(tag, core.TagPrefix))
+			where[i] = util.NewPair(core.ResolveTagAlias(k, aliases), v)
+		}
+		filters.Functions = append(filters.Functions, core.FilterByTagsAll(where))
+	}
+
+	changes, err := t.UpdateTags(filters, fn, options.dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to change tags: %s", err)
+	}
+
+	for _, c := range changes {
+		out.Print("%s\n", c.Path)
+		out.Print("  - %s\n", strings.ReplaceAll(c.OldNote, "\n", "\n    "))
+		out.Print("  + %s\n", strings.ReplaceAll(c.Record.Note, "\n", "\n    "))
+	}
+	if options.dryRun {
+		out.Success("Dry run: would change %d record files\n", len(changes))
+		return nil
+	}
+	out.Success("Changed %d record files\n", len(changes))
+	return nil
+}
+
+func checkTagName(name string) error {
+	if name == "" || strings.ContainsAny(name, " =\n") {
+		return fmt.Errorf("invalid tag name '%s'", name)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSet(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	records := []core.Record{
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 8, 0, 0), End: util.DateTime(2001, 2, 3, 9, 0, 0), Note: "A +client=acme", Tags: map[string]string{"client": "acme"}},
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 9, 0, 0), End: util.DateTime(2001, 2, 3, 10, 0, 0), Note: "B +client=foo", Tags: map[string]string{"client": "foo"}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"tag", "set", "--where", "client=acme", "+billable", "+rate=90"})
+	out.StdOut = bytes.NewBufferString("")
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	rec, err := track.LoadRecord(util.DateTime(2001, 2, 3, 8, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "A +client=acme +billable +rate=90", rec.Note, "Wrong note")
+	assert.Equal(t, map[string]string{"client": "acme", "billable": "", "rate": "90"}, rec.Tags, "Wrong tags")
+
+	rec, err = track.LoadRecord(util.DateTime(2001, 2, 3, 9, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "B +client=foo", rec.Note, "Record not matching --where should not change")
+}
+
+func TestTagRenameAlias(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+	track.Config.TagAliases = map[string]string{"mtg": "meeting", "cl": "client"}
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	records := []core.Record{
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 8, 0, 0), End: util.DateTime(2001, 2, 3, 9, 0, 0), Note: "A +mtg +cl=acme", Tags: map[string]string{"mtg": "", "cl": "acme"}},
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 9, 0, 0), End: util.DateTime(2001, 2, 3, 10, 0, 0), Note: "B +mtg +cl=foo", Tags: map[string]string{"mtg": "", "cl": "foo"}},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"tag", "rename", "mtg", "meeting", "--where", "cl=acme"})
+	out.StdOut = bytes.NewBufferString("")
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("error executing command: %s", err)
+	}
+
+	rec, err := track.LoadRecord(util.DateTime(2001, 2, 3, 8, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "A +meeting +cl=acme", rec.Note, "Aliased tag should be renamed")
+	assert.Equal(t, map[string]string{"meeting": "", "client": "acme"}, rec.Tags, "Wrong tags")
+
+	rec, err = track.LoadRecord(util.DateTime(2001, 2, 3, 9, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "B +mtg +cl=foo", rec.Note, "Record not matching --where should not change")
+}
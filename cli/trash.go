@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
+	"github.com/spf13/cobra"
+)
+
+func restoreCommand(t *core.Track) *cobra.Command {
+	restore := &cobra.Command{
+		Use:   "restore ID...",
+		Short: "Restore deleted records and projects from the trash",
+		Long: `Restore deleted records and projects from the trash
+
+Use $ track list trash to find the IDs of trash entries.
+Restoring fails if any of the deleted files exists again.
+Deleted records can only be restored if their project exists.`,
+		Args: util.WrappedArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, id := range args {
+				entry, err := t.LoadTrashEntry(id)
+				if err != nil {
+					return fmt.Errorf("failed to restore '%s': %s", id, err)
+				}
+				if err = t.RestoreTrashEntry(&entry); err != nil {
+					return fmt.Errorf("failed to restore '%s': %s", id, err)
+				}
+				out.Success("Restored %s '%s' (%d files)\n", entry.Kind, entry.Name, len(entry.Files))
+			}
+			return nil
+		},
+	}
+
+	return restore
+}
+
+func emptyTrashCommand(t *core.Track) *cobra.Command {
+	var olderThan string
+	var force bool
+	var dryRun bool
+
+	emptyTrash := &cobra.Command{
+		Use:   "empty-trash",
+		Short: "Permanently delete entries from the trash",
+		Long: `Permanently delete entries from the trash
+
+By default, all entries in the trash of the current workspace are deleted.
+Use flag --older-than to keep recently deleted entries, like --older-than 30d`,
+		Args: util.WrappedArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before := time.Now()
+			if olderThan != "" {
+				dur, err := util.ParseDuration(olderThan)
+				if err != nil {
+					return fmt.Errorf("failed to empty trash: %s", err)
+				}
+				before = before.Add(-dur)
+			}
+
+			entries, skipped, err := t.LoadTrash()
+			if err != nil {
+				return fmt.Errorf("failed to empty trash: %s", err)
+			}
+			warnSkippedTrash(skipped)
+			selected := []core.TrashEntry{}
+			for _, e := range entries {
+				if e.Deleted.Before(before) {
+					selected = append(selected, e)
+				}
+			}
+			if len(selected) == 0 {
+				out.Success("No trash entries to delete")
+				return nil
+			}
+
+			if !force && !confirm(
+				fmt.Sprintf("Really permanently delete %d trash entries? (y/n): ", len(selected)),
+				"y",
+			) {
+				return fmt.Errorf("failed to empty trash: aborted by user")
+			}
+
+			if !dryRun {
+				for _, e := range selected {
+					if err := t.DeleteTrashEntry(&e); err != nil {
+						return fmt.Errorf("failed to empty trash: %s", err)
+					}
+				}
+			}
+
+			if dryRun {
+				out.Success("Deleted %d trash entries - dry-run", len(selected))
+			} else {
+				out.Success("Deleted %d trash entries", len(selected))
+			}
+			return nil
+		},
+	}
+
+	emptyTrash.Flags().StringVar(&olderThan, "older-than", "", "Only delete entries deleted longer ago than the given duration, like 30d or 12h")
+	emptyTrash.Flags().BoolVarP(&force, "force", "F", false, "Don't prompt for confirmation.")
+	emptyTrash.Flags().BoolVar(&dryRun, "dry", false, "Dry run: do not actually change any files")
+
+	return emptyTrash
+}
+
+// warnSkippedTrash prints a warning for each trash entry that could not be read
+func warnSkippedTrash(skipped []error) {
+	for _, err := range skipped {
+		out.Warn("skipping %s\n", err)
+	}
+}
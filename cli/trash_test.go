@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreAndEmptyTrash(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.Remove(track.RootDir)
+
+	project := core.NewProject("test", "", "t", []string{}, 15, 0)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	record := core.Record{
+		Project: "test",
+		Start:   util.DateTime(2001, 2, 3, 4, 5, 0),
+		End:     util.DateTime(2001, 2, 3, 5, 5, 0),
+	}
+	err = track.SaveRecord(&record, false)
+	if err != nil {
+		t.Fatal("error saving record")
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"delete", "record", "2001-02-03", "04:05", "--force"})
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatal("error executing command")
+	}
+
+	entries, _, err := track.LoadTrash()
+	if err != nil || len(entries) != 1 {
+		t.Fatal("expecting one trash entry")
+	}
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"restore", entries[0].ID})
+	err = cmd.Execute()
+	assert.Nil(t, err, "error restoring record")
+
+	_, err = track.LoadRecord(record.Start)
+	assert.Nil(t, err, "record should be restored")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"restore", "foo"})
+	err = cmd.Execute()
+	assert.NotNil(t, err, "restoring missing entry should fail")
+
+	err = track.DeleteRecord(&record, "test")
+	if err != nil {
+		t.Fatal("error deleting record")
+	}
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"empty-trash", "--older-than", "1d", "--force"})
+	err = cmd.Execute()
+	assert.Nil(t, err, "error emptying trash")
+
+	entries, _, err = track.LoadTrash()
+	assert.Nil(t, err, "error loading trash")
+	assert.Equal(t, 1, len(entries), "recent entries should be kept")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"empty-trash", "--force"})
+	err = cmd.Execute()
+	assert.Nil(t, err, "error emptying trash")
+
+	entries, _, err = track.LoadTrash()
+	assert.Nil(t, err, "error loading trash")
+	assert.Equal(t, 0, len(entries), "trash should be empty")
+}
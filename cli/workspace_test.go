@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/mlange-42/track/core"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,3 +29,40 @@ func TestSwitchWorkspace(t *testing.T) {
 	}
 	assert.Equal(t, "move-to", track.Workspace(), "Should be in new workspace")
 }
+
+func TestCreateWorkspaceFrom(t *testing.T) {
+	track, err := setupTestCommand()
+	if err != nil {
+		t.Fatal("error setting up test")
+	}
+	defer os.RemoveAll(track.RootDir)
+
+	err = track.SaveProject(core.NewProject("test", "", "t", []string{}, 15, 0), false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	cmd := RootCommand(track, "")
+	cmd.SetArgs([]string{"create", "workspace", "client", "--projects-only"})
+	assert.NotNil(t, cmd.Execute(), "Should fail without --from")
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"create", "workspace", "client", "--from", "default", "--projects-only"})
+	assert.Nil(t, cmd.Execute())
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"rename", "workspace", "client", "acme"})
+	assert.Nil(t, cmd.Execute())
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"archive", "workspace", "acme"})
+	assert.Nil(t, cmd.Execute())
+	archived, err := track.IsWorkspaceArchived("acme")
+	assert.Nil(t, err)
+	assert.True(t, archived)
+
+	cmd = RootCommand(track, "")
+	cmd.SetArgs([]string{"delete", "workspace", "acme", "--force"})
+	assert.Nil(t, cmd.Execute())
+	assert.False(t, track.WorkspaceExists("acme"))
+}
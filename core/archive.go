@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CheckArchived returns an error if a project or any of its ancestors is archived
+func (t *Track) CheckArchived(name string) error {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return err
+	}
+	node, ok := tree.Nodes[name]
+	if !ok || node.Parent == nil {
+		return fmt.Errorf("no project named '%s'", name)
+	}
+	if node.Value.Archived {
+		return fmt.Errorf("project '%s' is archived", name)
+	}
+	ancestors, _ := tree.Ancestors(name)
+	for _, a := range ancestors {
+		if a.Value.Archived {
+			return fmt.Errorf("parent project '%s' of '%s' is archived", a.Value.Name, name)
+		}
+	}
+	return nil
+}
+
+// ArchiveProject archives or un-archives a project.
+//
+// If recursive is true, all descendants are archived or un-archived, too.
+// Otherwise, archiving fails for projects with non-archived descendants.
+// Un-archiving fails for projects with an archived ancestor.
+// Archiving fails if a record of any affected project is running.
+// Returns the names of the changed projects.
+func (t *Track) ArchiveProject(name string, archive, recursive bool) ([]string, error) {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return nil, err
+	}
+	node, ok := tree.Nodes[name]
+	if !ok || node.Parent == nil {
+		return nil, fmt.Errorf("no project named '%s'", name)
+	}
+
+	descendants, _ := tree.Descendants(name)
+	affected := []Project{node.Value}
+	if recursive {
+		for _, d := range descendants {
+			affected = append(affected, d.Value)
+		}
+	}
+
+	if archive {
+		if !recursive {
+			active := 0
+			for _, d := range descendants {
+				if !d.Value.Archived {
+					active++
+				}
+			}
+			if active > 0 {
+				return nil, fmt.Errorf("'%s' has %d non-archived descendant project(s), use --recursive", name, active)
+			}
+		}
+		open, err := t.OpenRecord()
+		if err != nil {
+			return nil, err
+		}
+		if open != nil {
+			for _, p := range affected {
+				if p.Name == open.Project {
+					return nil, fmt.Errorf("project '%s' has a running record", p.Name)
+				}
+			}
+		}
+	} else {
+		ancestors, _ := tree.Ancestors(name)
+		for _, a := range ancestors {
+			if a.Value.Archived {
+				return nil, fmt.Errorf("parent project '%s' is archived", a.Value.Name)
+			}
+		}
+	}
+
+	changed := []string{}
+	for _, p := range affected {
+		if p.Archived == archive {
+			continue
+		}
+		p.Archived = archive
+		if err := t.SaveProject(p, true); err != nil {
+			return changed, err
+		}
+		changed = append(changed, p.Name)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveProject(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	assert.Nil(t, track.SaveProject(NewProject("parent", "", "p", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveProject(NewProject("child", "parent", "c", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveProject(NewProject("grandchild", "child", "g", []string{}, 0, 0), false))
+
+	_, err = track.ArchiveProject("missing", true, false)
+	assert.NotNil(t, err)
+	_, err = track.ArchiveProject("parent", true, false)
+	assert.NotNil(t, err, "Should fail for non-archived descendants")
+
+	changed, err := track.ArchiveProject("grandchild", true, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"grandchild"}, changed)
+
+	changed, err = track.ArchiveProject("parent", true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"child", "parent"}, changed)
+	assert.NotNil(t, track.CheckArchived("child"))
+
+	_, err = track.ArchiveProject("child", false, false)
+	assert.NotNil(t, err, "Should fail for archived parent")
+
+	changed, err = track.ArchiveProject("parent", false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"parent"}, changed)
+	assert.Nil(t, track.CheckArchived("parent"))
+	assert.NotNil(t, track.CheckArchived("child"))
+
+	// Child un-archived by hand, with archived parent
+	child, err := track.LoadProject("child")
+	assert.Nil(t, err)
+	child.Archived = false
+	assert.Nil(t, track.SaveProject(child, true))
+	parent, err := track.LoadProject("parent")
+	assert.Nil(t, err)
+	parent.Archived = true
+	assert.Nil(t, track.SaveProject(parent, true))
+	err = track.CheckArchived("child")
+	assert.NotNil(t, err)
+	assert.Equal(t, "parent project 'parent' of 'child' is archived", err.Error())
+
+	changed, err = track.ArchiveProject("parent", false, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"grandchild", "parent"}, changed)
+	assert.Nil(t, track.CheckArchived("grandchild"))
+
+	_, err = track.StartRecord(&child, "", map[string]string{}, util.DateTime(2023, 3, 13, 9, 0, 0))
+	assert.Nil(t, err)
+	_, err = track.ArchiveProject("parent", true, true)
+	assert.NotNil(t, err, "Should fail for running record")
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/mlange-42/track/out"
+	"github.com/mlange-42/track/util"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +35,16 @@ type Config struct {
 	RecordCell string `yaml:"recordCell"`
 	// Character for pause cells in day and week reports
 	PauseCell string `yaml:"pauseCell"`
+	// First day of the week, like "monday" or "sunday"
+	WeekStart string `yaml:"weekStart"`
+	// Custom reporting periods, like fiscal months
+	Period util.Period `yaml:"period"`
+	// Default rounding policy for reports with rounding
+	Rounding Rounding `yaml:"rounding"`
+	// Tag aliases, applied when reading records, like "mtg: meeting"
+	TagAliases map[string]string `yaml:"tagAliases"`
+	// Color theme for messages and highlights, like "default", "bright" or "mono"
+	Theme string `yaml:"theme"`
 }
 
 // defaultConfig creates a Config with default values
@@ -51,6 +63,11 @@ func defaultConfig() Config {
 		EmptyCell:        ".",
 		RecordCell:       ":",
 		PauseCell:        "-",
+		WeekStart:        "monday",
+		Period:           util.Period{Months: 1, StartDay: 1, StartMonth: 1},
+		Rounding:         Rounding{Mode: RoundNearest, Per: RoundPerRecord},
+		TagAliases:       map[string]string{},
+		Theme:            out.DefaultTheme,
 	}
 }
 
@@ -132,5 +149,216 @@ func (conf *Config) Check() error {
 	if utf8.RuneCountInString(conf.PauseCell) != 1 {
 		return fmt.Errorf("config entry PauseCell must be a string of length 1. Got '%s'.\n%s", conf.PauseCell, versionHint)
 	}
+	if _, err := conf.FirstWeekday(); err != nil {
+		return fmt.Errorf("config entry WeekStart: %s", err)
+	}
+	if err := conf.Period.Check(); err != nil {
+		return fmt.Errorf("config entry Period: %s", err)
+	}
+	if err := conf.Rounding.Check(); err != nil {
+		return fmt.Errorf("config entry Rounding: %s", err)
+	}
+	if err := checkTagAliases(conf.TagAliases); err != nil {
+		return fmt.Errorf("config entry TagAliases: %s", err)
+	}
+	if err := out.CheckTheme(conf.Theme); err != nil {
+		return fmt.Errorf("config entry Theme: %s", err)
+	}
+	return nil
+}
+
+// FirstWeekday returns the first day of the week.
+// Defaults to monday if not set.
+func (conf *Config) FirstWeekday() (time.Weekday, error) {
+	if conf.WeekStart == "" {
+		return time.Monday, nil
+	}
+	return util.ParseWeekday(conf.WeekStart)
+}
+
+// Calendar returns the calendar settings week start and custom period.
+// Falls back to monday for an invalid week start, which is reported by Check.
+func (conf *Config) Calendar() util.Calendar {
+	cal := util.Calendar{FirstWeekday: time.Monday, Period: conf.Period}
+	if wd, err := conf.FirstWeekday(); err == nil {
+		cal.FirstWeekday = wd
+	}
+	return cal
+}
+
+// WorkspaceConfig holds workspace-specific overrides of the global config.
+// Entries that are not set are taken from the global config.
+type WorkspaceConfig struct {
+	// The text editor for editing resources
+	TextEditor *string `yaml:"textEditor,omitempty"`
+	// Maximum duration of breaks between records of the same project to consider it as a pause
+	MaxBreakDuration *time.Duration `yaml:"maxBreakDuration,omitempty"`
+	// Character for empty cells in day and week reports
+	EmptyCell *string `yaml:"emptyCell,omitempty"`
+	// Character for record cells in day and week reports
+	RecordCell *string `yaml:"recordCell,omitempty"`
+	// Character for pause cells in day and week reports
+	PauseCell *string `yaml:"pauseCell,omitempty"`
+	// First day of the week, like "monday" or "sunday"
+	WeekStart *string `yaml:"weekStart,omitempty"`
+	// Custom reporting periods, like fiscal months
+	Period *util.Period `yaml:"period,omitempty"`
+	// Default rounding policy for reports with rounding
+	Rounding *Rounding `yaml:"rounding,omitempty"`
+	// Tag aliases, applied when reading records, like "mtg: meeting"
+	TagAliases map[string]string `yaml:"tagAliases,omitempty"`
+	// Color theme for messages and highlights, like "default", "bright" or "mono"
+	Theme *string `yaml:"theme,omitempty"`
+	// Whether the workspace is archived. Not an override of the global config.
+	Archived bool `yaml:"archived,omitempty"`
+}
+
+// ConfigEntry is an entry of the effective config, with the source of its value
+type ConfigEntry struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Apply returns a copy of the given config, with the workspace overrides applied
+func (ws *WorkspaceConfig) Apply(conf Config) Config {
+	if ws.TextEditor != nil {
+		conf.TextEditor = *ws.TextEditor
+	}
+	if ws.MaxBreakDuration != nil {
+		conf.MaxBreakDuration = *ws.MaxBreakDuration
+	}
+	if ws.EmptyCell != nil {
+		conf.EmptyCell = *ws.EmptyCell
+	}
+	if ws.RecordCell != nil {
+		conf.RecordCell = *ws.RecordCell
+	}
+	if ws.PauseCell != nil {
+		conf.PauseCell = *ws.PauseCell
+	}
+	if ws.WeekStart != nil {
+		conf.WeekStart = *ws.WeekStart
+	}
+	if ws.Period != nil {
+		conf.Period = *ws.Period
+	}
+	if ws.Rounding != nil {
+		conf.Rounding = *ws.Rounding
+	}
+	if ws.TagAliases != nil {
+		conf.TagAliases = ws.TagAliases
+	}
+	if ws.Theme != nil {
+		conf.Theme = *ws.Theme
+	}
+	return conf
+}
+
+// LoadWorkspaceConfig loads a workspace config.
+// Returns an empty WorkspaceConfig if the file does not exist.
+func LoadWorkspaceConfig(path string) (WorkspaceConfig, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return WorkspaceConfig{}, nil
+		}
+		return WorkspaceConfig{}, err
+	}
+
+	var conf WorkspaceConfig
+	if err := yaml.Unmarshal(file, &conf); err != nil {
+		return WorkspaceConfig{}, err
+	}
+	return conf, nil
+}
+
+// Save saves the given WorkspaceConfig to the given path
+func (ws *WorkspaceConfig) Save(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bytes, err := yaml.Marshal(ws)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(file, "%s Track workspace config\n%s Entries override the global config\n\n", YamlCommentPrefix, YamlCommentPrefix)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bytes)
+
+	return err
+}
+
+// loadWorkspaceConfig loads the config of the current workspace,
+// and sets the effective config.
+func (t *Track) loadWorkspaceConfig() error {
+	wsConf, conf, err := t.workspaceConfig(t.GlobalConfig.Workspace)
+	if err != nil {
+		return err
+	}
+	t.WorkspaceConfig = wsConf
+	t.Config = conf
 	return nil
 }
+
+// workspaceConfig loads the config of a workspace,
+// and returns it together with the effective config for that workspace.
+func (t *Track) workspaceConfig(ws string) (WorkspaceConfig, Config, error) {
+	wsConf, err := LoadWorkspaceConfig(t.WorkspaceConfigPath(ws))
+	if err != nil {
+		return WorkspaceConfig{}, Config{}, fmt.Errorf("workspace config: %s", err)
+	}
+
+	conf := wsConf.Apply(t.GlobalConfig)
+	conf.Workspace = ws
+	if err := conf.Check(); err != nil {
+		return WorkspaceConfig{}, Config{}, fmt.Errorf("workspace config '%s': %s", ws, err)
+	}
+	return wsConf, conf, nil
+}
+
+// EffectiveConfig returns all entries of the effective config,
+// with information whether they are overridden by the workspace config.
+func (t *Track) EffectiveConfig() ([]ConfigEntry, error) {
+	var effective yaml.Node
+	if err := effective.Encode(&t.Config); err != nil {
+		return nil, err
+	}
+	overridden := map[string]interface{}{}
+	bytes, err := yaml.Marshal(&t.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(bytes, &overridden); err != nil {
+		return nil, err
+	}
+
+	entries := []ConfigEntry{}
+	for i := 0; i+1 < len(effective.Content); i += 2 {
+		key, value := effective.Content[i], effective.Content[i+1]
+
+		valueStr := value.Value
+		if value.Kind != yaml.ScalarNode {
+			value.Style = yaml.FlowStyle
+			bytes, err := yaml.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			valueStr = strings.TrimSpace(string(bytes))
+		}
+
+		source := "global"
+		if _, ok := overridden[key.Value]; ok {
+			source = fmt.Sprintf("workspace '%s'", t.Workspace())
+		}
+		entries = append(entries, ConfigEntry{Key: key.Value, Value: valueStr, Source: source})
+	}
+	return entries, nil
+}
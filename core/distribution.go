@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mlange-42/track/util"
+)
+
+// Distribution holds the distribution of time over weekdays and times of the day
+type Distribution struct {
+	// Duration of time slots
+	Slot time.Duration
+	// Total time per weekday and time slot
+	Time [7][]time.Duration
+	// Number of days of each weekday in the time range
+	Days [7]int
+	// Statistics of work days
+	Workdays WorkdayStats
+}
+
+// WorkdayStats holds statistics of work days
+type WorkdayStats struct {
+	// Number of days with records
+	Days int
+	// Median start of the work day, as duration since midnight
+	MedianStart time.Duration
+	// Median end of the work day, as duration since midnight
+	MedianEnd time.Duration
+	// Number of breaks, from gaps between records and from pauses
+	Breaks int
+	// Median duration of breaks
+	MedianBreak time.Duration
+	// Total duration of breaks
+	TotalBreak time.Duration
+}
+
+// NewDistribution calculates the distribution of the time in the given records
+// over weekdays and time slots, for the time range between start and end.
+// Gaps between records up to maxBreak are considered as breaks.
+func NewDistribution(records []Record, start, end time.Time, slot time.Duration, maxBreak time.Duration) (*Distribution, error) {
+	if slot <= 0 || (24*time.Hour)%slot != 0 {
+		return nil, fmt.Errorf("time slot %s does not divide a day", slot)
+	}
+	slots := int(24 * time.Hour / slot)
+
+	dist := Distribution{Slot: slot}
+	for i := range dist.Time {
+		dist.Time[i] = make([]time.Duration, slots)
+	}
+	for date := util.ToDate(start); date.Before(end); date = util.AddDays(date, 1) {
+		dist.Days[date.Weekday()]++
+	}
+
+	now := time.Now()
+	for _, rec := range records {
+		recEnd := rec.End
+		if recEnd.IsZero() {
+			recEnd = now
+		}
+		segStart := rec.Start
+		for _, p := range rec.Pause {
+			dist.addSegment(segStart, p.Start, start, end)
+			segStart = p.End
+			if p.End.IsZero() {
+				// Ongoing pause of a running record
+				segStart = recEnd
+			}
+		}
+		dist.addSegment(segStart, recEnd, start, end)
+	}
+
+	dist.Workdays = workdayStats(records, start, end, maxBreak)
+
+	return &dist, nil
+}
+
+// addSegment adds the time of a segment to the slots it covers, clipped to min and max
+func (d *Distribution) addSegment(segStart, segEnd, min, max time.Time) {
+	if !min.IsZero() && segStart.Before(min) {
+		segStart = min
+	}
+	if !max.IsZero() && segEnd.After(max) {
+		segEnd = max
+	}
+	for segStart.Before(segEnd) {
+		date := util.ToDate(segStart)
+		slot := int(segStart.Sub(date) / d.Slot)
+		if slot >= len(d.Time[0]) {
+			// Can happen on days with DST changes
+			slot = len(d.Time[0]) - 1
+		}
+		slotEnd := date.Add(time.Duration(slot+1) * d.Slot)
+		if slot == len(d.Time[0])-1 {
+			slotEnd = util.AddDays(date, 1)
+		}
+		if slotEnd.After(segEnd) {
+			slotEnd = segEnd
+		}
+		d.Time[date.Weekday()][slot] += slotEnd.Sub(segStart)
+		segStart = slotEnd
+	}
+}
+
+// Average returns the average time per day for a weekday and time slot
+func (d *Distribution) Average(weekday time.Weekday, slot int) time.Duration {
+	if d.Days[weekday] == 0 {
+		return 0
+	}
+	return d.Time[weekday][slot] / time.Duration(d.Days[weekday])
+}
+
+// DayAverage returns the average total time per day for a weekday
+func (d *Distribution) DayAverage(weekday time.Weekday) time.Duration {
+	if d.Days[weekday] == 0 {
+		return 0
+	}
+	total := time.Duration(0)
+	for _, t := range d.Time[weekday] {
+		total += t
+	}
+	return total / time.Duration(d.Days[weekday])
+}
+
+// workdayStats calculates statistics of work days
+func workdayStats(records []Record, start, end time.Time, maxBreak time.Duration) WorkdayStats {
+	days := map[time.Time][]Record{}
+	for _, rec := range records {
+		if (!start.IsZero() && rec.Start.Before(start)) || (!end.IsZero() && !rec.Start.Before(end)) {
+			continue
+		}
+		date := util.ToDate(rec.Start)
+		days[date] = append(days[date], rec)
+	}
+
+	stats := WorkdayStats{Days: len(days)}
+	if len(days) == 0 {
+		return stats
+	}
+
+	now := time.Now()
+	starts := []time.Duration{}
+	ends := []time.Duration{}
+	breaks := []time.Duration{}
+	for date, recs := range days {
+		sort.Slice(recs, func(i, j int) bool { return recs[i].Start.Before(recs[j].Start) })
+
+		dayEnd := date
+		for i, rec := range recs {
+			recEnd := rec.End
+			if recEnd.IsZero() {
+				recEnd = now
+			}
+			if recEnd.After(dayEnd) {
+				dayEnd = recEnd
+			}
+			for _, p := range rec.Pause {
+				if !p.End.IsZero() {
+					breaks = append(breaks, p.End.Sub(p.Start))
+				}
+			}
+			if i > 0 {
+				gap := rec.Start.Sub(recs[i-1].End)
+				if gap > 0 && gap <= maxBreak {
+					breaks = append(breaks, gap)
+				}
+			}
+		}
+		starts = append(starts, recs[0].Start.Sub(date))
+		ends = append(ends, dayEnd.Sub(date))
+	}
+
+	stats.MedianStart = median(starts)
+	stats.MedianEnd = median(ends)
+	stats.Breaks = len(breaks)
+	stats.MedianBreak = median(breaks)
+	for _, b := range breaks {
+		stats.TotalBreak += b
+	}
+	return stats
+}
+
+// median calculates the median of durations
+func median(values []time.Duration) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribution(t *testing.T) {
+	records := []Record{
+		{
+			Project: "test",
+			Start:   util.DateTime(2023, 3, 13, 9, 0, 0),
+			End:     util.DateTime(2023, 3, 13, 12, 0, 0),
+			Pause: []Pause{
+				{Start: util.DateTime(2023, 3, 13, 10, 0, 0), End: util.DateTime(2023, 3, 13, 10, 30, 0)},
+			},
+		},
+		{
+			Project: "test",
+			Start:   util.DateTime(2023, 3, 13, 12, 30, 0),
+			End:     util.DateTime(2023, 3, 13, 17, 0, 0),
+		},
+		{
+			Project: "test",
+			Start:   util.DateTime(2023, 3, 14, 8, 30, 0),
+			End:     util.DateTime(2023, 3, 14, 16, 0, 0),
+		},
+	}
+
+	_, err := NewDistribution(records, util.Date(2023, 3, 13), util.Date(2023, 3, 27), 7*time.Minute, time.Hour)
+	assert.NotNil(t, err, "Expected error for slot not dividing a day")
+
+	dist, err := NewDistribution(records, util.Date(2023, 3, 13), util.Date(2023, 3, 27), time.Hour, time.Hour)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, dist.Days[time.Monday], "Wrong number of Mondays")
+	assert.Equal(t, 24, len(dist.Time[time.Monday]), "Wrong number of slots")
+
+	assert.Equal(t, 30*time.Minute, dist.Average(time.Monday, 9), "Wrong average")
+	assert.Equal(t, 15*time.Minute, dist.Average(time.Monday, 10), "Wrong average with pause")
+	assert.Equal(t, 15*time.Minute, dist.Average(time.Tuesday, 8), "Wrong average")
+	assert.Equal(t, time.Duration(0), dist.Average(time.Wednesday, 10), "Wrong average")
+
+	assert.Equal(t, 3*time.Hour+30*time.Minute, dist.DayAverage(time.Monday), "Wrong day average")
+	assert.Equal(t, 3*time.Hour+45*time.Minute, dist.DayAverage(time.Tuesday), "Wrong day average")
+
+	assert.Equal(t, WorkdayStats{
+		Days:        2,
+		MedianStart: 8*time.Hour + 45*time.Minute,
+		MedianEnd:   16*time.Hour + 30*time.Minute,
+		Breaks:      2,
+		MedianBreak: 30 * time.Minute,
+		TotalBreak:  time.Hour,
+	}, dist.Workdays, "Wrong work day statistics")
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+// fileMove is a file to be moved between workspaces
+type fileMove struct {
+	From string
+	To   string
+}
+
+// MoveProjects moves projects to another workspace,
+// including all their descendants and the records of all these projects.
+//
+// The parent/child structure of the moved projects is kept.
+// Parents of the given projects are removed if no project with the same name exists in the target workspace.
+// Fails without changing any files if any project or record already exists in the target workspace.
+// Returns the number of moved projects and records.
+func (t *Track) MoveProjects(names []string, workspace string, dryRun bool) (int, int, error) {
+	if err := t.checkMoveTarget(workspace); err != nil {
+		return 0, 0, err
+	}
+
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return 0, 0, err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		node, ok := tree.Nodes[name]
+		if !ok || node.Parent == nil {
+			return 0, 0, fmt.Errorf("no project named '%s'", name)
+		}
+		selected[name] = true
+		desc, _ := tree.Descendants(name)
+		for _, d := range desc {
+			selected[d.Value.Name] = true
+		}
+	}
+
+	names = maps.Keys(selected)
+	sort.Strings(names)
+
+	moves := []fileMove{}
+	// Projects with a parent that does not exist in the target workspace are re-written instead of moved
+	reparent := []Project{}
+	for _, name := range names {
+		project := projects[name]
+		if project.Parent != "" && !selected[project.Parent] && !util.FileExists(t.workspaceProjectPath(workspace, project.Parent)) {
+			project.Parent = ""
+			reparent = append(reparent, project)
+			continue
+		}
+		moves = append(moves, fileMove{t.ProjectPath(name), t.workspaceProjectPath(workspace, name)})
+	}
+
+	records, err := t.LoadAllRecordsFiltered(NewFilter(
+		[]FilterFunction{FilterByProjects(names)}, util.NoTime, util.NoTime,
+	))
+	if err != nil {
+		return 0, 0, err
+	}
+	recMoves, err := t.recordMoves(records, workspace)
+	if err != nil {
+		return 0, 0, err
+	}
+	moves = append(moves, recMoves...)
+
+	targets := make([]string, 0, len(moves)+len(reparent))
+	for _, m := range moves {
+		targets = append(targets, m.To)
+	}
+	for _, p := range reparent {
+		targets = append(targets, t.workspaceProjectPath(workspace, p.Name))
+	}
+	if err := checkTargets(targets); err != nil {
+		return 0, 0, err
+	}
+	if dryRun {
+		return len(selected), len(records), nil
+	}
+
+	// Reparented projects are written to the target and removed from the source only after all files were moved,
+	// so that a failure can be rolled back completely
+	if err := executeMoves(moves, func() error {
+		written := []string{}
+		removed := []Project{}
+		undo := func(err error) error {
+			for _, w := range written {
+				_ = os.Remove(w)
+			}
+			for _, p := range removed {
+				_ = saveProjectFile(t.ProjectPath(p.Name), projects[p.Name])
+			}
+			return err
+		}
+		for _, p := range reparent {
+			path := t.workspaceProjectPath(workspace, p.Name)
+			if err := saveProjectFile(path, p); err != nil {
+				return undo(err)
+			}
+			written = append(written, path)
+		}
+		for _, p := range reparent {
+			if err := os.Remove(t.ProjectPath(p.Name)); err != nil {
+				return undo(err)
+			}
+			removed = append(removed, p)
+		}
+		return nil
+	}); err != nil {
+		return 0, 0, err
+	}
+	t.removeEmptyRecordDirsFor(records)
+
+	return len(selected), len(records), nil
+}
+
+// MoveRecords moves records to another workspace.
+//
+// The projects of all records must exist in the target workspace.
+// Fails without changing any files if any record already exists in the target workspace.
+func (t *Track) MoveRecords(records []Record, workspace string, dryRun bool) error {
+	if err := t.checkMoveTarget(workspace); err != nil {
+		return err
+	}
+
+	missing := map[string]bool{}
+	for _, rec := range records {
+		if !util.FileExists(t.workspaceProjectPath(workspace, rec.Project)) {
+			missing[rec.Project] = true
+		}
+	}
+	if len(missing) > 0 {
+		names := maps.Keys(missing)
+		sort.Strings(names)
+		return fmt.Errorf("projects missing in workspace '%s': %s", workspace, strings.Join(names, ", "))
+	}
+
+	moves, err := t.recordMoves(records, workspace)
+	if err != nil {
+		return err
+	}
+	targets := make([]string, len(moves))
+	for i, m := range moves {
+		targets[i] = m.To
+	}
+	if err := checkTargets(targets); err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := executeMoves(moves, nil); err != nil {
+		return err
+	}
+	t.removeEmptyRecordDirsFor(records)
+	return nil
+}
+
+// checkMoveTarget checks that a workspace exists and is not the current one
+func (t *Track) checkMoveTarget(workspace string) error {
+	if !t.WorkspaceExists(workspace) {
+		return fmt.Errorf("workspace '%s' does not exist", workspace)
+	}
+	if t.Workspace() == workspace {
+		return fmt.Errorf("already in workspace '%s'", workspace)
+	}
+	return nil
+}
+
+// recordMoves creates file moves for records. Fails for running records.
+func (t *Track) recordMoves(records []Record, workspace string) ([]fileMove, error) {
+	moves := make([]fileMove, len(records))
+	for i, rec := range records {
+		if !rec.HasEnded() {
+			return nil, fmt.Errorf("record %s in '%s' is still running", rec.Start.Format(util.DateTimeFormat), rec.Project)
+		}
+		moves[i] = fileMove{t.RecordPath(rec.Start), t.workspaceRecordPath(workspace, rec.Start)}
+	}
+	return moves, nil
+}
+
+// removeEmptyRecordDirsFor removes empty record directories after records were moved away
+func (t *Track) removeEmptyRecordDirsFor(records []Record) {
+	for _, rec := range records {
+		_ = removeEmptyRecordDirs(t.RecordDir(rec.Start))
+	}
+}
+
+// checkTargets checks that none of the target files exists
+func checkTargets(targets []string) error {
+	collisions := []string{}
+	for _, path := range targets {
+		if util.FileExists(path) {
+			collisions = append(collisions, path)
+		}
+	}
+	if len(collisions) > 0 {
+		return fmt.Errorf("%d file(s) already exist in the target workspace, e.g. %s", len(collisions), collisions[0])
+	}
+	return nil
+}
+
+// executeMoves moves all files, and runs the optional finalize function.
+// On any error, files that were already moved are moved back,
+// and directories that were created for them are removed.
+func executeMoves(moves []fileMove, finalize func() error) error {
+	done := 0
+	created := []string{}
+	rollback := func(err error) error {
+		for i := done - 1; i >= 0; i-- {
+			if e := os.Rename(moves[i].To, moves[i].From); e != nil {
+				return fmt.Errorf("%s; rollback failed for %s: %s", err, moves[i].From, e)
+			}
+		}
+		for i := len(created) - 1; i >= 0; i-- {
+			_ = os.Remove(created[i])
+		}
+		return err
+	}
+
+	for _, m := range moves {
+		dir := filepath.Dir(m.To)
+		missing := missingDirs(dir)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return rollback(err)
+		}
+		created = append(created, missing...)
+		if err := os.Rename(m.From, m.To); err != nil {
+			return rollback(err)
+		}
+		done++
+	}
+	if finalize != nil {
+		if err := finalize(); err != nil {
+			return rollback(err)
+		}
+	}
+	return nil
+}
+
+// missingDirs returns the directories of a path that do not exist yet, from the outermost to the innermost
+func missingDirs(dir string) []string {
+	missing := []string{}
+	for !util.DirExists(dir) {
+		missing = append([]string{dir}, missing...)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return missing
+}
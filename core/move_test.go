@@ -0,0 +1,35 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMovesRollback(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	from := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(from, []byte("a"), 0644); err != nil {
+		t.Fatal("error writing file")
+	}
+	moves := []fileMove{{From: from, To: filepath.Join(dir, "x", "y", "a.txt")}}
+
+	err = executeMoves(moves, func() error { return fmt.Errorf("finalize failed") })
+	assert.NotNil(t, err, "Expected error from finalize")
+	assert.True(t, util.FileExists(from), "File should be moved back")
+	assert.False(t, util.DirExists(filepath.Join(dir, "x")), "Created directories should be removed")
+
+	err = executeMoves(moves, nil)
+	assert.Nil(t, err)
+	assert.True(t, util.FileExists(moves[0].To), "File should be moved")
+	assert.False(t, util.FileExists(from), "File should be moved")
+}
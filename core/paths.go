@@ -18,6 +18,11 @@ func (t *Track) ConfigPath() string {
 	return filepath.Join(t.RootDir, configFile)
 }
 
+// WorkspaceConfigPath returns the path of the config file of a workspace
+func (t *Track) WorkspaceConfigPath(ws string) string {
+	return filepath.Join(t.RootDir, ws, configFile)
+}
+
 // ProjectsDirName returns the directory name for projects
 func (t *Track) ProjectsDirName() string {
 	return projectsDirName
@@ -30,7 +35,12 @@ func (t *Track) ProjectsDir() string {
 
 // ProjectPath returns the full path for a project
 func (t *Track) ProjectPath(name string) string {
-	return filepath.Join(t.ProjectsDir(), util.Sanitize(name)+".yml")
+	return t.workspaceProjectPath(t.Workspace(), name)
+}
+
+// workspaceProjectPath returns the full path for a project in the given workspace
+func (t *Track) workspaceProjectPath(ws, name string) string {
+	return filepath.Join(t.RootDir, ws, t.ProjectsDirName(), util.Sanitize(name)+".yml")
 }
 
 // RecordsDirName returns the directory name for records
@@ -55,8 +65,16 @@ func (t *Track) RecordsDir() string {
 
 // RecordPath returns the full path for a record
 func (t *Track) RecordPath(tm time.Time) string {
+	return t.workspaceRecordPath(t.Workspace(), tm)
+}
+
+// workspaceRecordPath returns the full path for a record in the given workspace
+func (t *Track) workspaceRecordPath(ws string, tm time.Time) string {
 	return filepath.Join(
-		t.RecordDir(tm),
+		t.RootDir, ws, t.RecordsDirName(),
+		fmt.Sprintf("%04d", tm.Year()),
+		fmt.Sprintf("%02d", int(tm.Month())),
+		fmt.Sprintf("%02d", tm.Day()),
 		fmt.Sprintf("%s.trk", tm.Format(util.FileTimeFormat)),
 	)
 }
@@ -0,0 +1,292 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// PivotProject groups by project
+	PivotProject = "project"
+	// PivotWorkspace groups by workspace
+	PivotWorkspace = "workspace"
+	// PivotTag groups by tag names. Use "tag:NAME" to group by the values of a tag.
+	PivotTag = "tag"
+	// PivotDay groups by date
+	PivotDay = "day"
+	// PivotWeekday groups by weekday
+	PivotWeekday = "weekday"
+	// PivotWeek groups by ISO week
+	PivotWeek = "week"
+	// PivotMonth groups by month
+	PivotMonth = "month"
+	// PivotYear groups by year
+	PivotYear = "year"
+)
+
+// PivotKeys are the keys for grouping in pivot tables, in addition to project fields like "client" or "meta.KEY"
+var PivotKeys = []string{
+	PivotProject, FieldClient, FieldMetadataPrefix + "KEY", PivotWorkspace, PivotTag, PivotTag + ":NAME",
+	PivotDay, PivotWeekday, PivotWeek, PivotMonth, PivotYear,
+}
+
+// PivotCell holds the aggregated durations of a pivot table cell
+type PivotCell struct {
+	Work  time.Duration
+	Pause time.Duration
+}
+
+func (c *PivotCell) add(work, pause time.Duration) {
+	c.Work += work
+	c.Pause += pause
+}
+
+// PivotTable is a cross-table of durations, grouped by keys for rows and columns.
+// Records with multiple labels for a key, like several tags, contribute to each of them,
+// but only once to totals.
+type PivotTable struct {
+	RowKey    string
+	ColKey    string
+	Rows      []string
+	Cols      []string
+	Cells     map[string]map[string]*PivotCell
+	RowTotals map[string]*PivotCell
+	ColTotals map[string]*PivotCell
+	Total     PivotCell
+}
+
+// Cell returns the cell for a row and column label. Returns an empty cell if there is none.
+func (p *PivotTable) Cell(row, col string) PivotCell {
+	if cols, ok := p.Cells[row]; ok {
+		if cell, ok := cols[col]; ok {
+			return *cell
+		}
+	}
+	return PivotCell{}
+}
+
+// noValueSort sorts labels for missing values last
+const noValueSort = "\uffff"
+
+// pivotLabel is a group label, with a key for sorting
+type pivotLabel struct {
+	Label string
+	Sort  string
+}
+
+// pivotKeyFunc returns the group labels of a record, for the day starting at date
+type pivotKeyFunc func(rec *Record, date time.Time) []pivotLabel
+
+// CheckPivotKey checks that a key can be used for grouping in pivot tables
+func CheckPivotKey(key string) error {
+	_, _, err := (&Reporter{}).pivotKey(key)
+	return err
+}
+
+// pivotKey returns the grouping function for a key, and whether it depends on the date
+func (r *Reporter) pivotKey(key string) (pivotKeyFunc, bool, error) {
+	if strings.HasPrefix(key, PivotTag+":") && len(key) > len(PivotTag)+1 {
+		name := strings.TrimPrefix(key, PivotTag+":")
+		return func(rec *Record, date time.Time) []pivotLabel {
+			if value, ok := rec.Tags[name]; ok && value != "" {
+				return []pivotLabel{{value, value}}
+			}
+			return []pivotLabel{{NoFieldValue, noValueSort}}
+		}, false, nil
+	}
+	if CheckProjectField(key) == nil {
+		return func(rec *Record, date time.Time) []pivotLabel {
+			value := ProjectFieldFor(rec.Project, key, r.ProjectsTree)
+			if value == "" {
+				return []pivotLabel{{NoFieldValue, noValueSort}}
+			}
+			return []pivotLabel{{value, value}}
+		}, false, nil
+	}
+
+	switch key {
+	case PivotProject:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			return []pivotLabel{{rec.Project, rec.Project}}
+		}, false, nil
+	case PivotWorkspace:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			ws := r.Track.Workspace()
+			if r.Track.IsMultiWorkspace() {
+				ws, _ = SplitNamespace(rec.Project)
+			}
+			return []pivotLabel{{ws, ws}}
+		}, false, nil
+	case PivotTag:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			if len(rec.Tags) == 0 {
+				return []pivotLabel{{NoFieldValue, noValueSort}}
+			}
+			labels := make([]pivotLabel, 0, len(rec.Tags))
+			for tag := range rec.Tags {
+				labels = append(labels, pivotLabel{tag, tag})
+			}
+			return labels
+		}, false, nil
+	case PivotDay:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			label := date.Format(util.DateFormat)
+			return []pivotLabel{{label, label}}
+		}, true, nil
+	case PivotWeekday:
+		cal := r.Track.Config.Calendar()
+		return func(rec *Record, date time.Time) []pivotLabel {
+			wd := date.Weekday()
+			idx := cal.WeekdayIndex(wd)
+			return []pivotLabel{{wd.String()[:3], fmt.Sprint(idx)}}
+		}, true, nil
+	case PivotWeek:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			year, week := date.ISOWeek()
+			label := fmt.Sprintf("%04d-W%02d", year, week)
+			return []pivotLabel{{label, label}}
+		}, true, nil
+	case PivotMonth:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			label := date.Format("2006-01")
+			return []pivotLabel{{label, label}}
+		}, true, nil
+	case PivotYear:
+		return func(rec *Record, date time.Time) []pivotLabel {
+			label := date.Format("2006")
+			return []pivotLabel{{label, label}}
+		}, true, nil
+	}
+	return nil, false, fmt.Errorf("unknown pivot key '%s', use one of: %s", key, strings.Join(PivotKeys, ", "))
+}
+
+// Pivot aggregates the reporter's records into a cross-table, with rows and columns grouped by the given keys.
+// Records spanning midnight are split between days for date-based keys.
+func (r *Reporter) Pivot(rowKey, colKey string) (*PivotTable, error) {
+	rowFunc, rowByDate, err := r.pivotKey(rowKey)
+	if err != nil {
+		return nil, err
+	}
+	colFunc, colByDate, err := r.pivotKey(colKey)
+	if err != nil {
+		return nil, err
+	}
+
+	table := PivotTable{
+		RowKey:    rowKey,
+		ColKey:    colKey,
+		Cells:     map[string]map[string]*PivotCell{},
+		RowTotals: map[string]*PivotCell{},
+		ColTotals: map[string]*PivotCell{},
+	}
+	rowSort := map[string]string{}
+	colSort := map[string]string{}
+
+	now := time.Now()
+	for i := range r.Records {
+		rec := &r.Records[i]
+		end := rec.End
+		if end.IsZero() {
+			end = now
+		}
+		if !rowByDate && !colByDate {
+			table.add(rec, util.NoTime, r.start, r.end, rowFunc, colFunc, rowSort, colSort)
+			continue
+		}
+		for date := util.ToDate(rec.Start); date.Before(end); date = util.AddDays(date, 1) {
+			min, max := date, util.AddDays(date, 1)
+			if !r.start.IsZero() && r.start.After(min) {
+				min = r.start
+			}
+			if !r.end.IsZero() && r.end.Before(max) {
+				max = r.end
+			}
+			if !min.Before(max) {
+				continue
+			}
+			table.add(rec, date, min, max, rowFunc, colFunc, rowSort, colSort)
+		}
+	}
+
+	table.Rows = sortedLabels(rowSort)
+	table.Cols = sortedLabels(colSort)
+
+	return &table, nil
+}
+
+// add adds the durations of a record between min and max to the table
+func (p *PivotTable) add(
+	rec *Record, date, min, max time.Time,
+	rowFunc, colFunc pivotKeyFunc,
+	rowSort, colSort map[string]string,
+) {
+	work := rec.Duration(min, max)
+	pause := rec.PauseDuration(min, max)
+	if work <= 0 && pause <= 0 {
+		return
+	}
+	rows := uniqueLabels(rowFunc(rec, date), rowSort)
+	cols := uniqueLabels(colFunc(rec, date), colSort)
+
+	p.Total.add(work, pause)
+	for _, col := range cols {
+		cell, ok := p.ColTotals[col]
+		if !ok {
+			cell = &PivotCell{}
+			p.ColTotals[col] = cell
+		}
+		cell.add(work, pause)
+	}
+	for _, row := range rows {
+		cell, ok := p.RowTotals[row]
+		if !ok {
+			cell = &PivotCell{}
+			p.RowTotals[row] = cell
+		}
+		cell.add(work, pause)
+
+		cells, ok := p.Cells[row]
+		if !ok {
+			cells = map[string]*PivotCell{}
+			p.Cells[row] = cells
+		}
+		for _, col := range cols {
+			cell, ok := cells[col]
+			if !ok {
+				cell = &PivotCell{}
+				cells[col] = cell
+			}
+			cell.add(work, pause)
+		}
+	}
+}
+
+// uniqueLabels returns the unique labels, and registers their sort keys
+func uniqueLabels(labels []pivotLabel, sortKeys map[string]string) []string {
+	result := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if _, ok := sortKeys[l.Label]; !ok {
+			sortKeys[l.Label] = l.Sort
+		}
+		result = append(result, l.Label)
+	}
+	return util.Unique(result)
+}
+
+// sortedLabels returns the labels, ordered by their sort keys
+func sortedLabels(sortKeys map[string]string) []string {
+	labels := maps.Keys(sortKeys)
+	sort.Slice(labels, func(i, j int) bool {
+		si, sj := sortKeys[labels[i]], sortKeys[labels[j]]
+		if si == sj {
+			return labels[i] < labels[j]
+		}
+		return si < sj
+	})
+	return labels
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPivot(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	acme := NewProject("acme", "", "a", []string{}, 0, 0)
+	acme.Client = "ACME"
+	assert.Nil(t, track.SaveProject(acme, false))
+	assert.Nil(t, track.SaveProject(NewProject("misc", "", "m", []string{}, 0, 0), false))
+
+	records := []Record{
+		{
+			Project: "acme",
+			Start:   util.DateTime(2023, 3, 13, 9, 0, 0),
+			End:     util.DateTime(2023, 3, 13, 11, 0, 0),
+			Note:    "+a +b",
+			Tags:    map[string]string{"a": "", "b": ""},
+			Pause:   []Pause{{Start: util.DateTime(2023, 3, 13, 10, 0, 0), End: util.DateTime(2023, 3, 13, 10, 30, 0)}},
+		},
+		{
+			Project: "misc",
+			Start:   util.DateTime(2023, 3, 31, 23, 0, 0),
+			End:     util.DateTime(2023, 4, 1, 1, 0, 0),
+			Note:    "+a",
+			Tags:    map[string]string{"a": ""},
+		},
+	}
+	for i := range records {
+		assert.Nil(t, track.SaveRecord(&records[i], false))
+	}
+
+	reporter, err := NewReporter(&track, []string{}, NewFilter([]FilterFunction{}, util.NoTime, util.NoTime), false, util.NoTime, util.NoTime)
+	assert.Nil(t, err)
+
+	_, err = reporter.Pivot("foo", PivotMonth)
+	assert.NotNil(t, err)
+	assert.Nil(t, CheckPivotKey("tag:client"))
+	assert.Nil(t, CheckPivotKey("meta.rate"))
+	assert.NotNil(t, CheckPivotKey("tag:"))
+
+	table, err := reporter.Pivot(PivotProject, PivotMonth)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"acme", "misc"}, table.Rows)
+	assert.Equal(t, []string{"2023-03", "2023-04"}, table.Cols)
+	assert.Equal(t, PivotCell{Work: 90 * time.Minute, Pause: 30 * time.Minute}, table.Cell("acme", "2023-03"))
+	assert.Equal(t, PivotCell{Work: time.Hour}, table.Cell("misc", "2023-03"))
+	assert.Equal(t, PivotCell{Work: time.Hour}, table.Cell("misc", "2023-04"))
+	assert.Equal(t, PivotCell{}, table.Cell("acme", "2023-04"))
+	assert.Equal(t, 150*time.Minute, table.ColTotals["2023-03"].Work)
+	assert.Equal(t, 210*time.Minute, table.Total.Work)
+
+	table, err = reporter.Pivot(PivotTag, FieldClient)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, table.Rows)
+	assert.Equal(t, []string{"ACME", NoFieldValue}, table.Cols)
+	assert.Equal(t, 210*time.Minute, table.RowTotals["a"].Work)
+	assert.Equal(t, 90*time.Minute, table.RowTotals["b"].Work)
+	assert.Equal(t, 90*time.Minute, table.ColTotals["ACME"].Work, "Records with multiple tags should count once in totals")
+	assert.Equal(t, 210*time.Minute, table.Total.Work)
+
+	table, err = reporter.Pivot(PivotWeekday, PivotYear)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Mon", "Fri", "Sat"}, table.Rows)
+}
@@ -5,7 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/gookit/color"
+	"github.com/mlange-42/track/out"
 	"github.com/mlange-42/track/util"
 	"gopkg.in/yaml.v3"
 )
@@ -29,25 +29,29 @@ type Project struct {
 	Name         string
 	Parent       string
 	RequiredTags []string `yaml:"requiredTags"`
-	Color        uint8
-	FgColor      uint8          `yaml:"fgColor"`
-	Render       color.Style256 `yaml:"-"`
+	Color        util.Color
+	FgColor      util.Color `yaml:"fgColor"`
+	Render       out.Style  `yaml:"-"`
 	Symbol       string
 	Archived     bool
+	Rounding     *Rounding         `yaml:",omitempty"`
+	TagSchema    TagSchema         `yaml:"tagSchema,omitempty"`
+	Description  string            `yaml:"description,omitempty"`
+	Client       string            `yaml:"client,omitempty"`
+	Links        ProjectLinks      `yaml:"links,omitempty"`
+	Metadata     map[string]string `yaml:"metadata,omitempty"`
 }
 
-// NewProject creates a new project
+// NewProject creates a new project, with colors from the 256 colors palette
 func NewProject(name string, parent string, symbol string, requiredTags []string, fgColor, color uint8) Project {
 	p := Project{
 		Name:         name,
 		Parent:       parent,
 		RequiredTags: requiredTags,
 		Symbol:       symbol,
-		Color:        color,
-		FgColor:      fgColor,
 		Archived:     false,
 	}
-	p.SetColors(fgColor, color)
+	p.SetColors(util.PaletteColor(fgColor), util.PaletteColor(color))
 	return p
 }
 
@@ -55,10 +59,16 @@ type tempProject struct {
 	Name         string
 	Parent       string
 	RequiredTags []string `yaml:"requiredTags"`
-	Color        uint8
-	FgColor      uint8 `yaml:"fgColor"`
+	Color        util.Color
+	FgColor      util.Color `yaml:"fgColor"`
 	Symbol       string
 	Archived     bool
+	Rounding     *Rounding         `yaml:",omitempty"`
+	TagSchema    TagSchema         `yaml:"tagSchema,omitempty"`
+	Description  string            `yaml:"description,omitempty"`
+	Client       string            `yaml:"client,omitempty"`
+	Links        ProjectLinks      `yaml:"links,omitempty"`
+	Metadata     map[string]string `yaml:"metadata,omitempty"`
 }
 
 // GetName implements the Named interface required for the MapTree
@@ -78,17 +88,32 @@ func (p *Project) UnmarshalYAML(value *yaml.Node) error {
 	p.RequiredTags = tmp.RequiredTags
 	p.Symbol = tmp.Symbol
 	p.Archived = tmp.Archived
+	p.Rounding = tmp.Rounding
+	p.TagSchema = tmp.TagSchema
+	p.Description = tmp.Description
+	p.Client = tmp.Client
+	p.Links = tmp.Links
+	p.Metadata = tmp.Metadata
+
+	if p.Rounding != nil {
+		if err := p.Rounding.Check(); err != nil {
+			return fmt.Errorf("project '%s': %s", p.Name, err)
+		}
+	}
+	if err := p.TagSchema.Validate(); err != nil {
+		return fmt.Errorf("project '%s': %s", p.Name, err)
+	}
 
 	p.SetColors(tmp.FgColor, tmp.Color)
 
 	return nil
 }
 
-// SetColors sets project colors
-func (p *Project) SetColors(fgCol, col uint8) {
+// SetColors sets project colors, as palette or true colors
+func (p *Project) SetColors(fgCol, col util.Color) {
 	p.Color = col
 	p.FgColor = fgCol
-	p.Render = *color.S256(fgCol, col)
+	p.Render = out.NewStyle(fgCol, col)
 }
 
 // ProjectExists checks if a project exists on disk
@@ -104,7 +129,11 @@ func (t *Track) SaveProject(project Project, force bool) error {
 	if !force && util.FileExists(path) {
 		return fmt.Errorf("Project '%s' already exists", project.Name)
 	}
+	return saveProjectFile(path, project)
+}
 
+// saveProjectFile saves a project to the given path, overwriting existing files
+func saveProjectFile(path string, project Project) error {
 	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
@@ -148,8 +177,12 @@ func (t *Track) loadProjectFromFile(path string) (Project, error) {
 	return project, nil
 }
 
-// LoadAllProjects loads all projects in the current workspace
+// LoadAllProjects loads all projects in the current workspace,
+// or in all workspaces set by ReadWorkspaces
 func (t *Track) LoadAllProjects() (map[string]Project, error) {
+	if t.IsMultiWorkspace() {
+		return t.loadAllProjectsMulti()
+	}
 	path := t.ProjectsDir()
 
 	files, err := os.ReadDir(path)
@@ -172,56 +205,84 @@ func (t *Track) LoadAllProjects() (map[string]Project, error) {
 	return projects, nil
 }
 
-// DeleteProject deletes a project and potentially all associated records
-//
-// Argument `deleteRecords` determines whether records should be deleted
-// Argument `dryRun` can be used to dry-run deleting
-func (t *Track) DeleteProject(project *Project, deleteRecords bool, dryRun bool) (int, error) {
-	counter := 0
-
+// DeleteProject deletes a project and potentially all associated records,
+// by moving them to the trash
+func (t *Track) DeleteProject(project *Project, deleteRecords bool, reason string, dryRun bool) (int, error) {
+	records := []Record{}
 	if deleteRecords {
-		// TODO: make a backup
 		filters := NewFilter(
-			[]func(r *Record) bool{
+			[]FilterFunction{
 				FilterByProjects([]string{project.Name}),
 			}, util.NoTime, util.NoTime,
 		)
-		fn, results, _ := t.AllRecordsFiltered(filters, false)
+		var err error
+		records, err = t.LoadAllRecordsFiltered(filters)
+		if err != nil {
+			return 0, err
+		}
+	}
 
-		go fn()
+	if dryRun {
+		return len(records), nil
+	}
 
-		for res := range results {
-			if res.Err != nil {
-				return counter, res.Err
-			}
-			if !dryRun {
-				t.DeleteRecord(&res.Record)
-			}
-			counter++
-		}
+	entry, err := t.newTrashEntry(TrashProject, project.Name, project.Name, reason)
+	if err != nil {
+		return 0, err
 	}
 
-	if !dryRun {
-		err := os.Remove(t.ProjectPath(project.Name))
-		if err != nil {
+	counter, err := t.moveProjectToTrash(project, records, &entry)
+	// Save the entry in any case, so that partial deletions can be restored
+	if saveErr := t.saveTrashEntry(&entry); err == nil {
+		err = saveErr
+	}
+	return counter, err
+}
+
+// moveProjectToTrash moves a project file and the given records into a trash entry
+func (t *Track) moveProjectToTrash(project *Project, records []Record, entry *TrashEntry) (int, error) {
+	counter := 0
+	for _, rec := range records {
+		path := t.RecordPath(rec.Start)
+		if err := t.moveToTrash(entry, path); err != nil {
 			return counter, err
 		}
+		if err := removeEmptyRecordDirs(filepath.Dir(path)); err != nil {
+			return counter, err
+		}
+		counter++
 	}
 
-	return counter, nil
+	return counter, t.moveToTrash(entry, t.ProjectPath(project.Name))
 }
 
 // ToProjectTree creates a MapTree of the given projects
 func (t *Track) ToProjectTree(projects map[string]Project) (*ProjectTree, error) {
+	rootName := t.WorkspaceLabel()
+	if t.IsMultiWorkspace() {
+		rootName = AllWorkspacesLabel
+	}
 	pTree := NewTree(
 		Project{
-			Name:   t.WorkspaceLabel(),
+			Name:   rootName,
 			Symbol: " ",
 		},
 	)
 
 	nodes := map[string]*ProjectNode{pTree.Root.Value.Name: pTree.Root}
 
+	// Nodes for workspaces, when reading multiple workspaces
+	wsNodes := map[string]*ProjectNode{}
+	if t.IsMultiWorkspace() {
+		for _, ws := range t.readWorkspaces {
+			node := util.NewNode(Project{Name: fmt.Sprintf(RootPattern, ws), Symbol: " "})
+			if err := pTree.AddNode(pTree.Root, node); err != nil {
+				return nil, err
+			}
+			wsNodes[ws] = node
+		}
+	}
+
 	for name, project := range projects {
 		nodes[name] = util.NewNode(project)
 	}
@@ -231,14 +292,15 @@ func (t *Track) ToProjectTree(projects map[string]Project) (*ProjectTree, error)
 			continue
 		}
 		var err error
-		if tree.Value.Parent == "" {
-			err = pTree.AddNode(pTree.Root, tree)
+		if tt, ok := nodes[tree.Value.Parent]; ok && tree.Value.Parent != "" {
+			err = pTree.AddNode(tt, tree)
 		} else {
-			if tt, ok := nodes[tree.Value.Parent]; ok {
-				err = pTree.AddNode(tt, tree)
-			} else {
-				err = pTree.AddNode(pTree.Root, tree)
+			parent := pTree.Root
+			ws, _ := SplitNamespace(tree.Value.Name)
+			if node, ok := wsNodes[ws]; ok {
+				parent = node
 			}
+			err = pTree.AddNode(parent, tree)
 		}
 		if err != nil {
 			return nil, err
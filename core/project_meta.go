@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/util"
+)
+
+const (
+	// FieldClient is the project field name of the client, for grouping
+	FieldClient = "client"
+	// FieldMetadataPrefix is the prefix of project metadata field names, for grouping, like "meta.rate"
+	FieldMetadataPrefix = "meta."
+	// NoFieldValue is the group label for projects without a value for a field
+	NoFieldValue = "<none>"
+)
+
+// ProjectLinks are external links of a project
+type ProjectLinks struct {
+	// URL pattern for issues, with placeholder {id}, like "https://github.com/user/repo/issues/{id}"
+	Issues string `yaml:"issues,omitempty"`
+	// URL of the repository
+	Repository string `yaml:"repository,omitempty"`
+}
+
+// IssueURL returns the URL of an issue, or an empty string if there is no pattern for issues
+func (l *ProjectLinks) IssueURL(id string) string {
+	if l.Issues == "" {
+		return ""
+	}
+	return util.Format(l.Issues, map[string]string{"id": id})
+}
+
+// CheckProjectField checks that a project field can be used for grouping
+func CheckProjectField(field string) error {
+	if field == FieldClient {
+		return nil
+	}
+	if strings.HasPrefix(field, FieldMetadataPrefix) && len(field) > len(FieldMetadataPrefix) {
+		return nil
+	}
+	return fmt.Errorf("unknown project field '%s', use '%s' or '%sKEY'", field, FieldClient, FieldMetadataPrefix)
+}
+
+// Field returns the value of a field of a project, like "client" or "meta.rate"
+func (p *Project) Field(field string) string {
+	if field == FieldClient {
+		return p.Client
+	}
+	return p.Metadata[strings.TrimPrefix(field, FieldMetadataPrefix)]
+}
+
+// ProjectFieldFor returns the value of a field of a project, like "client" or "meta.rate".
+// Uses the value of the closest ancestor if the project has none.
+func ProjectFieldFor(project, field string, tree *ProjectTree) string {
+	node := tree.Nodes[project]
+	for node != nil {
+		if value := node.Value.Field(field); value != "" {
+			return value
+		}
+		node = node.Parent
+	}
+	return ""
+}
+
+// FieldTotals calculates the total time per value of a project field, like "client" or "meta.rate".
+// Projects without a value are grouped under NoFieldValue.
+func (r *Reporter) FieldTotals(field string) (map[string]time.Duration, error) {
+	if err := CheckProjectField(field); err != nil {
+		return nil, err
+	}
+	totals := map[string]time.Duration{}
+	for project, dur := range r.ProjectTime {
+		if _, ok := r.Projects[project]; !ok {
+			continue
+		}
+		value := ProjectFieldFor(project, field, r.ProjectsTree)
+		if value == "" {
+			value = NoFieldValue
+		}
+		totals[value] += dur
+	}
+	return totals, nil
+}
+
+// ProjectStats are lifetime statistics of a project
+type ProjectStats struct {
+	Records int
+	First   time.Time
+	Last    time.Time
+	Total   time.Duration
+	// Tags with the highest total time, in descending order
+	TopTags []util.Pair[string, time.Duration]
+}
+
+// NewProjectStats calculates statistics from the records of a project, with the given maximum number of top tags
+func NewProjectStats(records []Record, topTags int) ProjectStats {
+	stats := ProjectStats{Records: len(records)}
+	tags := map[string]time.Duration{}
+	for _, rec := range records {
+		if stats.First.IsZero() || rec.Start.Before(stats.First) {
+			stats.First = rec.Start
+		}
+		if rec.Start.After(stats.Last) {
+			stats.Last = rec.Start
+		}
+		dur := rec.Duration(util.NoTime, util.NoTime)
+		stats.Total += dur
+		for tag := range rec.Tags {
+			tags[tag] += dur
+		}
+	}
+
+	for tag, dur := range tags {
+		stats.TopTags = append(stats.TopTags, util.NewPair(tag, dur))
+	}
+	sort.Slice(stats.TopTags, func(i, j int) bool {
+		if stats.TopTags[i].Value == stats.TopTags[j].Value {
+			return stats.TopTags[i].Key < stats.TopTags[j].Key
+		}
+		return stats.TopTags[i].Value > stats.TopTags[j].Value
+	})
+	if len(stats.TopTags) > topTags {
+		stats.TopTags = stats.TopTags[:topTags]
+	}
+	return stats
+}
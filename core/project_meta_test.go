@@ -0,0 +1,96 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestProjectMetadataYAML(t *testing.T) {
+	text := `name: dev
+parent: acme
+description: Development
+client: ACME Corp
+links:
+  issues: https://example.com/issues/{id}
+  repository: https://example.com/repo
+metadata:
+  rate: "90"
+`
+	var project Project
+	assert.Nil(t, yaml.Unmarshal([]byte(text), &project))
+	assert.Equal(t, "Development", project.Description)
+	assert.Equal(t, "ACME Corp", project.Client)
+	assert.Equal(t, "https://example.com/repo", project.Links.Repository)
+	assert.Equal(t, "https://example.com/issues/12", project.Links.IssueURL("12"))
+	assert.Equal(t, "90", project.Field("meta.rate"))
+	assert.Equal(t, "ACME Corp", project.Field(FieldClient))
+
+	bytes, err := yaml.Marshal(NewProject("test", "", "t", []string{}, 0, 0))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(bytes), "links", "Empty links should be omitted")
+	assert.NotContains(t, string(bytes), "metadata", "Empty metadata should be omitted")
+}
+
+func TestFieldTotals(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	acme := NewProject("acme", "", "a", []string{}, 0, 0)
+	acme.Client = "ACME"
+	dev := NewProject("dev", "acme", "d", []string{}, 0, 0)
+	dev.Metadata = map[string]string{"rate": "90"}
+	assert.Nil(t, track.SaveProject(acme, false))
+	assert.Nil(t, track.SaveProject(dev, false))
+	assert.Nil(t, track.SaveProject(NewProject("misc", "", "m", []string{}, 0, 0), false))
+
+	for i, p := range []string{"acme", "dev", "misc"} {
+		assert.Nil(t, track.SaveRecord(&Record{
+			Project: p,
+			Start:   util.DateTime(2023, 3, 13, 9+i, 0, 0),
+			End:     util.DateTime(2023, 3, 13, 9+i, 30, 0),
+			Tags:    map[string]string{"x": ""},
+		}, false))
+	}
+
+	reporter, err := NewReporter(&track, []string{}, NewFilter([]FilterFunction{}, util.NoTime, util.NoTime), false, util.NoTime, util.NoTime)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "ACME", ProjectFieldFor("dev", FieldClient, reporter.ProjectsTree), "Client should be inherited")
+
+	_, err = reporter.FieldTotals("foo")
+	assert.NotNil(t, err)
+
+	totals, err := reporter.FieldTotals(FieldClient)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]time.Duration{"ACME": time.Hour, NoFieldValue: 30 * time.Minute}, totals)
+
+	totals, err = reporter.FieldTotals("meta.rate")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]time.Duration{"90": 30 * time.Minute, NoFieldValue: time.Hour}, totals)
+}
+
+func TestProjectStats(t *testing.T) {
+	records := []Record{
+		{Start: util.DateTime(2023, 3, 14, 9, 0, 0), End: util.DateTime(2023, 3, 14, 10, 0, 0), Tags: map[string]string{"a": "", "b": ""}},
+		{Start: util.DateTime(2023, 3, 13, 9, 0, 0), End: util.DateTime(2023, 3, 13, 11, 0, 0), Tags: map[string]string{"b": ""}},
+		{Start: util.DateTime(2023, 3, 15, 9, 0, 0), End: util.DateTime(2023, 3, 15, 9, 30, 0), Tags: map[string]string{"c": ""}},
+	}
+	stats := NewProjectStats(records, 2)
+	assert.Equal(t, 3, stats.Records)
+	assert.Equal(t, util.DateTime(2023, 3, 13, 9, 0, 0), stats.First)
+	assert.Equal(t, util.DateTime(2023, 3, 15, 9, 0, 0), stats.Last)
+	assert.Equal(t, 210*time.Minute, stats.Total)
+	assert.Equal(t, []util.Pair[string, time.Duration]{
+		util.NewPair("b", 3*time.Hour),
+		util.NewPair("a", time.Hour),
+	}, stats.TopTags)
+}
@@ -146,7 +146,7 @@ func TestSaveLoadProject(t *testing.T) {
 	assert.Nil(t, err, "Error loading project")
 	assert.Equal(t, project, newProject, "Loaded project not equal to saved project")
 
-	_, err = track.DeleteProject(&project, true, false)
+	_, err = track.DeleteProject(&project, true, "test", false)
 	assert.Nil(t, err, "Error deleting project")
 
 	assert.False(t, util.FileExists(track.ProjectPath("test")), "File must not exist")
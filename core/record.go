@@ -33,6 +33,11 @@ type Record struct {
 	Note    string            `json:"note"`
 	Tags    map[string]string `json:"tags"`
 	Pause   []Pause           `json:"pause"`
+
+	// Adjustment of the duration by rounding, in memory only.
+	// Counted for time ranges that contain roundingAt.
+	rounding   time.Duration
+	roundingAt time.Time
 }
 
 // Pause holds information about a pause in a record
@@ -76,9 +81,13 @@ func (r *Record) LastPause() (Pause, bool) {
 }
 
 // Duration reports the duration of a record, excluding pause times.
+// Includes the adjustment by rounding of reporters, if the time range contains the rounded end.
 func (r *Record) Duration(min, max time.Time) time.Duration {
 	dur := util.DurationClip(r.Start, r.End, min, max)
 	dur -= r.PauseDuration(min, max)
+	if r.rounding != 0 && (min.IsZero() || r.roundingAt.After(min)) && (max.IsZero() || !r.roundingAt.After(max)) {
+		dur += r.rounding
+	}
 	return dur
 }
 
@@ -111,8 +120,8 @@ func (r *Record) CurrentPauseDuration(min, max time.Time) time.Duration {
 	return last.Duration(min, max)
 }
 
-// Check checks consistency of a record
-func (r *Record) Check(project *Project) error {
+// Check checks consistency of a record, including required tags and the tag schema of the project
+func (r *Record) Check(project *Project, schema TagSchema) error {
 	for _, tag := range project.RequiredTags {
 		if v, ok := r.Tags[tag]; ok {
 			if v == "" {
@@ -122,6 +131,9 @@ func (r *Record) Check(project *Project) error {
 			return fmt.Errorf("missing required tag '%s' for project '%s'", tag, project.Name)
 		}
 	}
+	if err := schema.Check(r.Tags); err != nil {
+		return fmt.Errorf("%s for project '%s'", err, project.Name)
+	}
 
 	if !r.End.IsZero() && r.End.Before(r.Start) {
 		return fmt.Errorf("end time is before start time")
@@ -28,8 +28,18 @@ type listFilterResult struct {
 	Err  error
 }
 
-// StartRecord starts and saves a new record
+// StartRecord starts and saves a new record.
+// Missing tags with a default value in the project's tag schema are added to the note and tags.
 func (t *Track) StartRecord(project *Project, note string, tags map[string]string, start time.Time) (Record, error) {
+	schema, err := t.LoadTagSchema(project.Name)
+	if err != nil {
+		return Record{}, err
+	}
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	note = schema.ApplyDefaults(note, tags)
+
 	record := Record{
 		Project: project.Name,
 		Note:    note,
@@ -39,13 +49,55 @@ func (t *Track) StartRecord(project *Project, note string, tags map[string]strin
 		Pause:   []Pause{},
 	}
 
-	if err := record.Check(project); err != nil {
+	if err := record.Check(project, schema); err != nil {
 		return record, err
 	}
 
 	return record, t.SaveRecord(&record, false)
 }
 
+// AddRecord checks and saves a completed record.
+// Fails if the record overlaps with any existing record, including a running one.
+func (t *Track) AddRecord(record *Record, project *Project) error {
+	if !record.HasEnded() {
+		return fmt.Errorf("record has no end time")
+	}
+	schema, err := t.LoadTagSchema(project.Name)
+	if err != nil {
+		return err
+	}
+	if err := record.Check(project, schema); err != nil {
+		return err
+	}
+
+	overlap, err := t.OverlappingRecords(record.Start, record.End)
+	if err != nil {
+		return err
+	}
+	if len(overlap) > 0 {
+		other := overlap[0]
+		return fmt.Errorf(
+			"record overlaps with record %s in '%s'",
+			other.Start.Format(util.DateTimeFormat), other.Project,
+		)
+	}
+
+	return t.SaveRecord(record, false)
+}
+
+// OverlappingRecords loads all records that overlap with the given time span.
+// Running records are considered to end now.
+func (t *Track) OverlappingRecords(start, end time.Time) ([]Record, error) {
+	// Files are loaded from one day earlier, to catch records that span midnight.
+	// Not using NewFilter, as it would add a second, looser time filter for that range.
+	filters := FilterFunctions{
+		Functions: []FilterFunction{FilterByTime(start, end)},
+		Start:     util.ToDate(start).Add(-24 * time.Hour),
+		End:       end,
+	}
+	return t.LoadAllRecordsFiltered(filters)
+}
+
 // StopRecord stops the currently running record at the given time, and saves it to disk.
 func (t *Track) StopRecord(end time.Time) (*Record, error) {
 	record, err := t.OpenRecord()
@@ -74,7 +126,8 @@ func (t *Track) StopRecord(end time.Time) (*Record, error) {
 	return record, nil
 }
 
-// LoadRecord loads a record by the given start time
+// LoadRecord loads a record by the given start time.
+// Tag aliases from the config are applied to the record's tags.
 func (t *Track) LoadRecord(tm time.Time) (Record, error) {
 	path := t.RecordPath(tm)
 	file, err := os.ReadFile(path)
@@ -89,6 +142,7 @@ func (t *Track) LoadRecord(tm time.Time) (Record, error) {
 	if err != nil {
 		return Record{}, err
 	}
+	ApplyTagAliases(record.Tags, t.Config.TagAliases)
 
 	return record, nil
 }
@@ -109,6 +163,9 @@ func (t *Track) OpenRecord() (*Record, error) {
 	if latest.HasEnded() {
 		return nil, nil
 	}
+	if t.IsMultiWorkspace() {
+		latest.Project = NamespaceProject(t.Workspace(), latest.Project)
+	}
 	return latest, nil
 }
 
@@ -205,11 +262,15 @@ func (t *Track) AllRecords() (func(), chan FilterResult, chan struct{}) {
 }
 
 // AllRecordsFiltered is an async version of LoadAllRecordsFiltered.
+// Reads from all workspaces set by ReadWorkspaces, if any.
 //
 // Returns a function to be run as goroutine,
 // a channel for results, and a channel that can be closed
 // to signal end of the search.
 func (t *Track) AllRecordsFiltered(filters FilterFunctions, reversed bool) (func(), chan FilterResult, chan struct{}) {
+	if t.IsMultiWorkspace() {
+		return t.allRecordsMulti(filters, reversed)
+	}
 	numWorkers := 32
 	results := make(chan FilterResult, 64)
 
@@ -404,6 +465,19 @@ func (t *Track) LoadDateRecordsExact(date time.Time) ([]Record, error) {
 	dateBefore := date.Add(-24 * time.Hour)
 	dateAfter := date.Add(24 * time.Hour)
 
+	if t.IsMultiWorkspace() {
+		records, err := t.LoadAllRecordsFiltered(FilterFunctions{
+			[]FilterFunction{FilterByTime(date, dateAfter)}, dateBefore, date,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, ErrNoRecords
+		}
+		return records, nil
+	}
+
 	filters := FilterFunctions{
 		[]FilterFunction{FilterByTime(date, dateAfter)},
 		util.NoTime,
@@ -513,40 +587,40 @@ func (t *Track) SaveRecord(record *Record, force bool) error {
 	return err
 }
 
-// DeleteRecord deletes a record
-func (t *Track) DeleteRecord(record *Record) error {
+// DeleteRecord deletes a record by moving it to the trash
+func (t *Track) DeleteRecord(record *Record, reason string) error {
 	path := t.RecordPath(record.Start)
 	if !util.FileExists(path) {
 		return fmt.Errorf("record does not exist")
 	}
-	err := os.Remove(path)
+	entry, err := t.newTrashEntry(TrashRecord, record.Start.Format(util.DateTimeFormat), record.Project, reason)
 	if err != nil {
 		return err
 	}
-	dayDir := filepath.Dir(path)
-	empty, err := util.DirIsEmpty(dayDir)
-	if err != nil {
+	if err := t.moveToTrash(&entry, path); err != nil {
+		t.DeleteTrashEntry(&entry)
 		return err
 	}
-	if empty {
-		os.Remove(dayDir)
-		monthDir := filepath.Dir(dayDir)
-		empty, err := util.DirIsEmpty(monthDir)
+	if err := t.saveTrashEntry(&entry); err != nil {
+		return err
+	}
+	return removeEmptyRecordDirs(filepath.Dir(path))
+}
+
+// removeEmptyRecordDirs removes the given day directory, as well as its
+// month and year directories, if they are empty
+func removeEmptyRecordDirs(dayDir string) error {
+	dir := dayDir
+	for i := 0; i < 3; i++ {
+		empty, err := util.DirIsEmpty(dir)
 		if err != nil {
 			return err
 		}
-		if empty {
-			os.Remove(monthDir)
-			yearDir := filepath.Dir(monthDir)
-			empty, err := util.DirIsEmpty(yearDir)
-			if err != nil {
-				return err
-			}
-			if empty {
-				os.Remove(yearDir)
-
-			}
+		if !empty {
+			return nil
 		}
+		os.Remove(dir)
+		dir = filepath.Dir(dir)
 	}
 	return nil
 }
@@ -89,7 +89,7 @@ func TestSaveLoadRecord(t *testing.T) {
 		assert.Equal(t, []Record{record1, record2, record3}, allRecords, "Loaded record not equal to saved record")
 	}
 
-	err = track.DeleteRecord(&record1)
+	err = track.DeleteRecord(&record1, "test")
 	assert.Nil(t, err, "Error deleting record")
 	assert.False(t, util.FileExists(track.RecordPath(record1.Start)), "File must exist")
 }
@@ -185,7 +185,7 @@ func TestCheckRecord(t *testing.T) {
 	}
 
 	for _, test := range tt {
-		err := test.record.Check(&test.project)
+		err := test.record.Check(&test.project, nil)
 		if err != nil {
 			if !test.expError {
 				t.Fatalf("got unexpected error in  %s: %s", test.title, err.Error())
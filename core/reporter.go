@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/mlange-42/track/util"
@@ -24,6 +25,8 @@ type Reporter struct {
 	AllProjects  map[string]Project
 	ProjectsTree *ProjectTree
 	TimeRange    TimeRange
+	start        time.Time
+	end          time.Time
 }
 
 // NewReporter creates a new Reporter from filters.
@@ -85,19 +88,8 @@ func NewReporter(
 		return nil, err
 	}
 
-	totals := make(map[string]time.Duration, len(projects)+1)
-	totals[projectsTree.Root.Value.Name] = time.Second * 0.0
-	for _, p := range projects {
-		totals[p.Name] = time.Second * 0.0
-	}
-
 	tRange := TimeRange{}
 	for _, rec := range records {
-		dur := rec.Duration(start, end)
-		if dur > 0 {
-			totals[rec.Project] = totals[rec.Project] + dur
-		}
-
 		// TODO should be able to get rid of this; only required for timelines
 		if tRange.Start.IsZero() || rec.Start.Before(tRange.Start) {
 			tRange.Start = rec.Start
@@ -113,25 +105,137 @@ func NewReporter(
 		}
 	}
 
+	report := Reporter{
+		Track:        t,
+		Records:      records,
+		Projects:     projects,
+		AllProjects:  allProjects,
+		ProjectsTree: projectsTree,
+		TimeRange:    tRange,
+		start:        start,
+		end:          end,
+	}
+	report.calcTotals()
+
+	return &report, nil
+}
+
+// calcTotals calculates time per project, and aggregated over the project tree
+func (r *Reporter) calcTotals() {
+	totals := make(map[string]time.Duration, len(r.Projects)+1)
+	totals[r.ProjectsTree.Root.Value.Name] = time.Second * 0.0
+	for _, p := range r.Projects {
+		totals[p.Name] = time.Second * 0.0
+	}
+
+	for _, rec := range r.Records {
+		dur := rec.Duration(r.start, r.end)
+		if dur > 0 {
+			totals[rec.Project] = totals[rec.Project] + dur
+		}
+	}
+
 	projectTotals := make(map[string]time.Duration, len(totals))
 	for k, v := range totals {
 		projectTotals[k] = v
 	}
 
 	util.Aggregate(
-		projectsTree, totals, 0,
+		r.ProjectsTree, totals, 0,
 		func(a, b time.Duration) time.Duration { return a + b },
 	)
 
-	report := Reporter{
-		Track:        t,
-		Records:      records,
-		Projects:     projects,
-		ProjectTime:  projectTotals,
-		TotalTime:    totals,
-		AllProjects:  allProjects,
-		ProjectsTree: projectsTree,
-		TimeRange:    tRange,
+	r.ProjectTime = projectTotals
+	r.TotalTime = totals
+}
+
+// Bounds returns the time range covered by the reporter.
+// Uses the range of the records for unbounded start or end.
+func (r *Reporter) Bounds() TimeRange {
+	bounds := TimeRange{Start: r.start, End: r.end}
+	if bounds.Start.IsZero() {
+		bounds.Start = r.TimeRange.Start
 	}
-	return &report, nil
+	if bounds.End.IsZero() {
+		bounds.End = r.TimeRange.End
+	}
+	return bounds
+}
+
+// TagStats holds time statistics for a tag
+type TagStats struct {
+	Count  int
+	Work   time.Duration
+	Pause  time.Duration
+	Values map[string]*TagValueStats
+}
+
+// TagValueStats holds time statistics for a value of a tag
+type TagValueStats struct {
+	Count int
+	Work  time.Duration
+	Pause time.Duration
+}
+
+// TagStats calculates time statistics for the given tags, or for all tags if none are given
+func (r *Reporter) TagStats(tags []string) map[string]*TagStats {
+	selected := map[string]bool{}
+	for _, tag := range tags {
+		k, _ := ParseTag(tag)
+		selected[k] = true
+	}
+
+	allTags := map[string]*TagStats{}
+	for _, rec := range r.Records {
+		dur := rec.Duration(util.NoTime, util.NoTime)
+		pause := rec.PauseDuration(util.NoTime, util.NoTime)
+		for tag, value := range rec.Tags {
+			if len(selected) > 0 && !selected[tag] {
+				continue
+			}
+			entry, ok := allTags[tag]
+			if !ok {
+				entry = &TagStats{Values: map[string]*TagValueStats{}}
+				allTags[tag] = entry
+			}
+			entry.Work += dur
+			entry.Pause += pause
+			entry.Count++
+
+			values, ok := entry.Values[value]
+			if !ok {
+				values = &TagValueStats{}
+				entry.Values[value] = values
+			}
+			values.Work += dur
+			values.Pause += pause
+			values.Count++
+		}
+	}
+	return allTags
+}
+
+// DayTotals calculates the total time per day, for the given number of days from start.
+// Records spanning midnight are split between days.
+func (r *Reporter) DayTotals(start time.Time, days int) []time.Duration {
+	start = util.ToDate(start)
+	totals := make([]time.Duration, days)
+	for _, rec := range r.Records {
+		end := rec.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		first := int(math.Round(util.ToDate(rec.Start).Sub(start).Hours() / 24))
+		if first < 0 {
+			first = 0
+		}
+		for d := first; d < days; d++ {
+			dayStart := util.AddDays(start, d)
+			if !dayStart.Before(end) {
+				break
+			}
+			totals[d] += rec.Duration(dayStart, util.AddDays(dayStart, 1))
+		}
+	}
+	return totals
 }
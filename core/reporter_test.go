@@ -63,9 +63,67 @@ func TestReporter(t *testing.T) {
 	}
 	assert.Equal(t, 11*time.Hour+30*time.Minute, reporter.TotalTime["test"], "Wrong total time")
 
+	tags := reporter.TagStats(nil)
+	assert.Equal(t, 3, len(tags), "Wrong number of tags")
+	assert.Equal(t, 23, tags["tag"].Count, "Wrong tag count")
+	assert.Equal(t, 11*time.Hour+30*time.Minute, tags["key"].Work, "Wrong tag time")
+	assert.Equal(t, 23, tags["key"].Values["value"].Count, "Wrong tag value count")
+
+	tags = reporter.TagStats([]string{"foo"})
+	assert.Equal(t, 1, len(tags), "Wrong number of tags")
+
+	bounds := reporter.Bounds()
+	assert.Equal(t, util.DateTime(2001, 2, 3, 0, 0, 0), bounds.Start, "Wrong start of bounds")
+	assert.Equal(t, util.DateTime(2001, 2, 3, 22, 30, 0), bounds.End, "Wrong end of bounds")
+
 	_, err = NewReporter(
 		&track, []string{"foo"}, FilterFunctions{},
 		false, util.NoTime, util.NoTime,
 	)
 	assert.NotNil(t, err, "expecting error on invalid project")
 }
+
+func TestDayTotals(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+
+	project := NewProject("test", "", "T", []string{}, 0, 15)
+	err = track.SaveProject(project, false)
+	if err != nil {
+		t.Fatal("error saving project")
+	}
+
+	records := []Record{
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 10, 0, 0), End: util.DateTime(2001, 2, 3, 12, 0, 0)},
+		{Project: "test", Start: util.DateTime(2001, 2, 3, 23, 0, 0), End: util.DateTime(2001, 2, 4, 1, 30, 0)},
+		{Project: "test", Start: util.DateTime(2001, 2, 6, 8, 0, 0), End: util.DateTime(2001, 2, 6, 9, 0, 0)},
+	}
+	for _, rec := range records {
+		err = track.SaveRecord(&rec, false)
+		if err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err := NewReporter(
+		&track, []string{}, FilterFunctions{},
+		false, util.NoTime, util.NoTime,
+	)
+	if err != nil {
+		t.Fatal("error creating reporter")
+	}
+
+	totals := reporter.DayTotals(util.Date(2001, 2, 3), 4)
+	assert.Equal(t, []time.Duration{3 * time.Hour, 90 * time.Minute, 0, time.Hour}, totals, "Wrong day totals")
+
+	totals = reporter.DayTotals(util.Date(2001, 2, 4), 2)
+	assert.Equal(t, []time.Duration{90 * time.Minute, 0}, totals, "Wrong day totals")
+}
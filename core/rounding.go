@@ -0,0 +1,250 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mlange-42/track/util"
+)
+
+// Rounding modes
+const (
+	// RoundNearest rounds to the nearest increment
+	RoundNearest = "nearest"
+	// RoundUp rounds up to the next increment
+	RoundUp = "up"
+	// RoundDown rounds down to the previous increment
+	RoundDown = "down"
+)
+
+// Rounding scopes
+const (
+	// RoundPerRecord applies rounding to each record individually
+	RoundPerRecord = "record"
+	// RoundPerDay applies rounding to the total time per project and day
+	RoundPerDay = "day"
+)
+
+// Rounding is a policy for rounding durations, e.g. for billing
+type Rounding struct {
+	// Rounding mode: nearest, up or down
+	Mode string `yaml:"mode"`
+	// Rounding increment, like 6m or 15m. No rounding if zero
+	Increment time.Duration `yaml:"increment"`
+	// Scope of rounding: per record or per day
+	Per string `yaml:"per"`
+	// Minimum billable duration, per record or day
+	Minimum time.Duration `yaml:"minimum"`
+}
+
+// IsZero reports whether the policy does not change any durations
+func (r *Rounding) IsZero() bool {
+	return r.Increment <= 0 && r.Minimum <= 0
+}
+
+// Check checks the policy for consistency
+func (r *Rounding) Check() error {
+	switch r.Mode {
+	case "", RoundNearest, RoundUp, RoundDown:
+	default:
+		return fmt.Errorf("invalid rounding mode '%s'", r.Mode)
+	}
+	switch r.Per {
+	case "", RoundPerRecord, RoundPerDay:
+	default:
+		return fmt.Errorf("invalid rounding scope '%s'", r.Per)
+	}
+	if r.Increment < 0 {
+		return fmt.Errorf("negative rounding increment")
+	}
+	if r.Minimum < 0 {
+		return fmt.Errorf("negative minimum duration")
+	}
+	return nil
+}
+
+// Round applies the policy to a duration.
+// The minimum duration is not applied to zero durations.
+func (r *Rounding) Round(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	if r.Increment > 0 {
+		switch r.Mode {
+		case RoundUp:
+			if rem := d % r.Increment; rem > 0 {
+				d += r.Increment - rem
+			}
+		case RoundDown:
+			d -= d % r.Increment
+		default:
+			d = d.Round(r.Increment)
+		}
+	}
+	if d < r.Minimum {
+		d = r.Minimum
+	}
+	return d
+}
+
+// RoundingFor returns the rounding policy for a project.
+// Uses the policy of the closest ancestor if the project has none,
+// and the global policy from the config if no ancestor has one.
+func (t *Track) RoundingFor(project string, tree *ProjectTree) Rounding {
+	if node, ok := tree.Nodes[project]; ok {
+		for node != nil {
+			if node.Value.Rounding != nil {
+				return *node.Value.Rounding
+			}
+			node = node.Parent
+		}
+	}
+	return t.Config.Rounding
+}
+
+// ApplyRounding applies the rounding policies of the projects to the reporter's records,
+// and re-calculates project totals.
+//
+// Rounding applies to the durations of records clipped to the reporter's time range.
+// The difference is counted for the time of the (clipped) end of the record,
+// while start and end times are not changed. Running records are not rounded.
+func (r *Reporter) ApplyRounding() error {
+	for i := range r.Records {
+		r.Records[i].rounding = 0
+	}
+	adjust, err := r.Track.roundingAdjustments(r.Records, r.ProjectsTree, r.start, r.end)
+	if err != nil {
+		return err
+	}
+	for i, delta := range adjust {
+		rec := &r.Records[i]
+		rec.rounding = delta
+		rec.roundingAt = rec.End
+		if !r.end.IsZero() && rec.End.After(r.end) {
+			rec.roundingAt = r.end
+		}
+	}
+
+	r.calcTotals()
+	return nil
+}
+
+// RoundRecords returns copies of records with the rounding policies of their projects applied.
+// Rounding changes the end times of the returned records, the stored records are not modified.
+// Running records are not rounded.
+func (t *Track) RoundRecords(records []Record) ([]Record, error) {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return nil, err
+	}
+	adjust, err := t.roundingAdjustments(records, tree, util.NoTime, util.NoTime)
+	if err != nil {
+		return nil, err
+	}
+
+	rounded := make([]Record, len(records))
+	copy(rounded, records)
+	for i, delta := range adjust {
+		rounded[i] = withDuration(records[i], records[i].Duration(util.NoTime, util.NoTime)+delta)
+	}
+	return rounded, nil
+}
+
+// roundingAdjustments calculates the differences between the rounded and the original durations
+// of records clipped to the given time range, by record index.
+// With rounding per day, the difference is distributed over the latest records of the day.
+func (t *Track) roundingAdjustments(records []Record, tree *ProjectTree, start, end time.Time) (map[int]time.Duration, error) {
+	type dayKey struct {
+		Project string
+		Date    time.Time
+	}
+	days := map[dayKey][]int{}
+	adjust := map[int]time.Duration{}
+
+	for i := range records {
+		rec := &records[i]
+		if !rec.HasEnded() {
+			continue
+		}
+		policy := t.RoundingFor(rec.Project, tree)
+		if err := policy.Check(); err != nil {
+			return nil, fmt.Errorf("rounding for project '%s': %s", rec.Project, err)
+		}
+		if policy.IsZero() {
+			continue
+		}
+		if policy.Per == RoundPerDay {
+			recStart := rec.Start
+			if !start.IsZero() && recStart.Before(start) {
+				recStart = start
+			}
+			key := dayKey{rec.Project, util.ToDate(recStart)}
+			days[key] = append(days[key], i)
+			continue
+		}
+		dur := rec.Duration(start, end)
+		if delta := policy.Round(dur) - dur; delta != 0 {
+			adjust[i] = delta
+		}
+	}
+
+	for key, indices := range days {
+		policy := t.RoundingFor(key.Project, tree)
+
+		sort.Slice(indices, func(i, j int) bool {
+			return records[indices[i]].Start.Before(records[indices[j]].Start)
+		})
+		total := time.Duration(0)
+		for _, idx := range indices {
+			total += records[idx].Duration(start, end)
+		}
+		delta := policy.Round(total) - total
+
+		// Apply the difference to the latest records of the day
+		for i := len(indices) - 1; i >= 0 && delta != 0; i-- {
+			dur := records[indices[i]].Duration(start, end)
+			newDur := dur + delta
+			if newDur < 0 {
+				newDur = 0
+			}
+			if newDur != dur {
+				adjust[indices[i]] = newDur - dur
+			}
+			delta -= newDur - dur
+		}
+	}
+
+	return adjust, nil
+}
+
+// withDuration returns a copy of a record with the end time adjusted
+// to result in the given duration, excluding pauses.
+// Pauses after the new end time are removed.
+func withDuration(r Record, dur time.Duration) Record {
+	curr := r.Duration(util.NoTime, util.NoTime)
+	if dur >= curr {
+		r.End = r.End.Add(dur - curr)
+		return r
+	}
+
+	remaining := dur
+	segStart := r.Start
+	pauses := []Pause{}
+	for _, p := range r.Pause {
+		seg := p.Start.Sub(segStart)
+		if seg >= remaining {
+			break
+		}
+		remaining -= seg
+		pauses = append(pauses, p)
+		segStart = p.End
+	}
+	r.Pause = pauses
+	r.End = segStart.Add(remaining)
+	return r
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundingRound(t *testing.T) {
+	tt := []struct {
+		title    string
+		rounding Rounding
+		duration time.Duration
+		expected time.Duration
+	}{
+		{
+			title:    "no rounding",
+			rounding: Rounding{},
+			duration: 7 * time.Minute,
+			expected: 7 * time.Minute,
+		},
+		{
+			title:    "nearest",
+			rounding: Rounding{Mode: RoundNearest, Increment: 15 * time.Minute},
+			duration: 22 * time.Minute,
+			expected: 15 * time.Minute,
+		},
+		{
+			title:    "nearest, default mode",
+			rounding: Rounding{Increment: 15 * time.Minute},
+			duration: 23 * time.Minute,
+			expected: 30 * time.Minute,
+		},
+		{
+			title:    "up",
+			rounding: Rounding{Mode: RoundUp, Increment: 6 * time.Minute},
+			duration: 61 * time.Minute,
+			expected: 66 * time.Minute,
+		},
+		{
+			title:    "up, exact",
+			rounding: Rounding{Mode: RoundUp, Increment: 6 * time.Minute},
+			duration: 60 * time.Minute,
+			expected: 60 * time.Minute,
+		},
+		{
+			title:    "down",
+			rounding: Rounding{Mode: RoundDown, Increment: 15 * time.Minute},
+			duration: 29 * time.Minute,
+			expected: 15 * time.Minute,
+		},
+		{
+			title:    "minimum",
+			rounding: Rounding{Mode: RoundDown, Increment: 15 * time.Minute, Minimum: 15 * time.Minute},
+			duration: 5 * time.Minute,
+			expected: 15 * time.Minute,
+		},
+		{
+			title:    "minimum, zero duration",
+			rounding: Rounding{Minimum: 15 * time.Minute},
+			duration: 0,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tt {
+		assert.Equal(t, test.expected, test.rounding.Round(test.duration), "Wrong rounded duration in %s", test.title)
+	}
+
+	assert.NotNil(t, (&Rounding{Mode: "foo"}).Check(), "Expected error for invalid mode")
+	assert.NotNil(t, (&Rounding{Per: "week"}).Check(), "Expected error for invalid scope")
+	assert.Nil(t, (&Rounding{Mode: RoundUp, Per: RoundPerDay, Increment: time.Minute}).Check(), "Expected no error")
+}
+
+func TestWithDuration(t *testing.T) {
+	record := Record{
+		Project: "test",
+		Start:   util.DateTime(2001, 2, 3, 10, 0, 0),
+		End:     util.DateTime(2001, 2, 3, 12, 0, 0),
+		Pause: []Pause{
+			{Start: util.DateTime(2001, 2, 3, 10, 30, 0), End: util.DateTime(2001, 2, 3, 10, 45, 0)},
+			{Start: util.DateTime(2001, 2, 3, 11, 30, 0), End: util.DateTime(2001, 2, 3, 11, 45, 0)},
+		},
+	}
+
+	rec := withDuration(record, 2*time.Hour)
+	assert.Equal(t, util.DateTime(2001, 2, 3, 12, 30, 0), rec.End, "Wrong end time")
+	assert.Equal(t, 2, len(rec.Pause), "Wrong number of pauses")
+
+	rec = withDuration(record, time.Hour)
+	assert.Equal(t, util.DateTime(2001, 2, 3, 11, 15, 0), rec.End, "Wrong end time")
+	assert.Equal(t, 1, len(rec.Pause), "Wrong number of pauses")
+	assert.Equal(t, time.Hour, rec.Duration(util.NoTime, util.NoTime), "Wrong duration")
+
+	assert.Equal(t, util.DateTime(2001, 2, 3, 12, 0, 0), record.End, "Original record should not be changed")
+}
+
+func TestApplyRounding(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+	track.Config.Rounding = Rounding{Mode: RoundUp, Increment: 15 * time.Minute, Per: RoundPerRecord}
+
+	parent := NewProject("parent", "", "P", []string{}, 0, 15)
+	parent.Rounding = &Rounding{Mode: RoundUp, Increment: 30 * time.Minute, Per: RoundPerDay}
+	for _, p := range []Project{
+		NewProject("global", "", "G", []string{}, 0, 15),
+		parent,
+		NewProject("child", "parent", "C", []string{}, 0, 15),
+	} {
+		if err = track.SaveProject(p, false); err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+
+	records := []Record{
+		{Project: "global", Start: util.DateTime(2001, 2, 3, 8, 0, 0), End: util.DateTime(2001, 2, 3, 8, 10, 0)},
+		{Project: "global", Start: util.DateTime(2001, 2, 3, 9, 0, 0), End: util.DateTime(2001, 2, 3, 9, 10, 0)},
+		{Project: "child", Start: util.DateTime(2001, 2, 3, 10, 0, 0), End: util.DateTime(2001, 2, 3, 10, 10, 0)},
+		{Project: "child", Start: util.DateTime(2001, 2, 3, 11, 0, 0), End: util.DateTime(2001, 2, 3, 11, 10, 0)},
+	}
+	for _, rec := range records {
+		if err = track.SaveRecord(&rec, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err := NewReporter(&track, []string{}, FilterFunctions{}, false, util.NoTime, util.NoTime)
+	if err != nil {
+		t.Fatal("error creating reporter")
+	}
+	assert.Equal(t, 20*time.Minute, reporter.ProjectTime["global"], "Wrong unrounded time")
+	assert.Equal(t, 20*time.Minute, reporter.TotalTime["parent"], "Wrong unrounded time")
+
+	err = reporter.ApplyRounding()
+	assert.Nil(t, err, "Error applying rounding")
+
+	assert.Equal(t, 30*time.Minute, reporter.ProjectTime["global"], "Wrong time rounded per record")
+	assert.Equal(t, 30*time.Minute, reporter.TotalTime["parent"], "Wrong time rounded per day")
+	assert.Equal(t, 60*time.Minute, reporter.TotalTime[track.WorkspaceLabel()], "Wrong total rounded time")
+
+	stored, err := track.LoadRecord(records[0].Start)
+	assert.Nil(t, err, "Error loading record")
+	assert.Equal(t, records[0].End, stored.End, "Stored record should not be changed")
+
+	late := []Record{
+		{Project: "global", Start: util.DateTime(2001, 2, 4, 23, 50, 0), End: util.DateTime(2001, 2, 4, 23, 57, 0)},
+		{Project: "global", Start: util.DateTime(2001, 2, 5, 23, 0, 0), End: util.DateTime(2001, 2, 6, 0, 20, 0)},
+	}
+	for _, rec := range late {
+		if err = track.SaveRecord(&rec, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err = NewReporter(&track, []string{"global"}, FilterFunctions{}, false, util.Date(2001, 2, 4), util.Date(2001, 2, 6))
+	if err != nil {
+		t.Fatal("error creating reporter")
+	}
+	err = reporter.ApplyRounding()
+	assert.Nil(t, err, "Error applying rounding")
+
+	assert.Equal(t, 75*time.Minute, reporter.ProjectTime["global"], "Rounding should apply to the clipped records")
+	assert.Equal(t,
+		[]time.Duration{15 * time.Minute, 60 * time.Minute},
+		reporter.DayTotals(util.Date(2001, 2, 4), 2),
+		"Wrong rounded time per day",
+	)
+	for _, rec := range reporter.Records {
+		if rec.Start.Equal(late[0].Start) {
+			assert.Equal(t, late[0].End, rec.End, "Rounding should not change end times")
+		}
+	}
+
+	exported, err := track.RoundRecords(late)
+	assert.Nil(t, err, "Error rounding records")
+	assert.Equal(t, util.DateTime(2001, 2, 5, 0, 5, 0), exported[0].End, "Wrong end time of rounded record")
+	assert.Equal(t, util.DateTime(2001, 2, 6, 0, 30, 0), exported[1].End, "Wrong end time of rounded record")
+	assert.Equal(t, util.DateTime(2001, 2, 4, 23, 57, 0), late[0].End, "Original records should not be changed")
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mlange-42/track/util"
+)
+
+// TagChange is a change of the note and tags of a record
+type TagChange struct {
+	// The record, with the new note and tags
+	Record Record
+	// The note before the change
+	OldNote string
+	// Path of the record file, relative to the workspace directory
+	Path string
+}
+
+// FormatTag formats a tag for use in a note
+func FormatTag(name, value string) string {
+	if value == "" {
+		return TagPrefix + name
+	}
+	return fmt.Sprintf("%s%s=%s", TagPrefix, name, value)
+}
+
+// RenameTag renames a tag in a note, keeping its value. Returns the new note.
+func RenameTag(note, oldName, newName string) string {
+	return mapTags(note, func(token, name, value string) (string, bool) {
+		if name != oldName {
+			return token, true
+		}
+		return FormatTag(newName, value), true
+	})
+}
+
+// SetTag sets the value of a tag in a note.
+// The tag is appended to the note if it is not present. Returns the new note.
+func SetTag(note, name, value string) string {
+	found := false
+	note = mapTags(note, func(token, n, v string) (string, bool) {
+		if n != name {
+			return token, true
+		}
+		found = true
+		return FormatTag(name, value), true
+	})
+	if found {
+		return note
+	}
+	if note == "" {
+		return FormatTag(name, value)
+	}
+	return note + " " + FormatTag(name, value)
+}
+
+// RemoveTag removes all occurrences of a tag from a note. Returns the new note.
+func RemoveTag(note, name string) string {
+	return mapTags(note, func(token, n, v string) (string, bool) {
+		return token, n != name
+	})
+}
+
+// mapTags applies a function to all tag tokens in a note.
+// The function returns the replacement token, and whether the token should be kept.
+// Lines with removed tokens are re-joined with single spaces.
+func mapTags(note string, fn func(token, name, value string) (string, bool)) string {
+	anyRemoved := false
+	lines := strings.Split(note, "\n")
+	for i, line := range lines {
+		tokens := strings.Split(line, " ")
+		result := make([]string, 0, len(tokens))
+		removed := false
+		for _, token := range tokens {
+			if !strings.HasPrefix(token, TagPrefix) || token == TagPrefix {
+				result = append(result, token)
+				continue
+			}
+			name, value := ParseTag(strings.TrimPrefix(token, TagPrefix))
+			newToken, keep := fn(token, name, value)
+			if !keep {
+				removed = true
+				continue
+			}
+			result = append(result, newToken)
+		}
+		if removed {
+			nonEmpty := result[:0]
+			for _, token := range result {
+				if token != "" {
+					nonEmpty = append(nonEmpty, token)
+				}
+			}
+			result = nonEmpty
+			anyRemoved = true
+		}
+		lines[i] = strings.Join(result, " ")
+	}
+	if anyRemoved {
+		return strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// UpdateTags applies a function to the notes of all records matching the filters,
+// and re-extracts the records' tags from the new notes.
+//
+// All changed records are checked against their project's required tags and tag schema
+// before any record is saved. If dryRun is true, no records are saved.
+// Returns the changes, in chronological order.
+func (t *Track) UpdateTags(filters FilterFunctions, fn func(note string) string, dryRun bool) ([]TagChange, error) {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := t.LoadAllRecordsFiltered(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []TagChange{}
+	for _, rec := range records {
+		note := fn(rec.Note)
+		if note == rec.Note {
+			continue
+		}
+		tags, err := ExtractTagsSlice(strings.Split(note, "\n"))
+		if err != nil {
+			return nil, fmt.Errorf("record %s: %s", rec.Start.Format(util.DateTimeFormat), err)
+		}
+		ApplyTagAliases(tags, t.Config.TagAliases)
+		change := TagChange{OldNote: rec.Note}
+		rec.Note = note
+		rec.Tags = tags
+
+		project := projects[rec.Project]
+		if err := rec.Check(&project, t.TagSchemaFor(rec.Project, tree)); err != nil {
+			return nil, fmt.Errorf("record %s: %s", rec.Start.Format(util.DateTimeFormat), err)
+		}
+
+		change.Record = rec
+		change.Path, err = filepath.Rel(t.WorkspaceDir(t.Workspace()), t.RecordPath(rec.Start))
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+	for i := range changes {
+		if err := t.SaveRecord(&changes[i].Record, true); err != nil {
+			return changes[:i], err
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditTags(t *testing.T) {
+	note := "Meeting +mtg +client=acme\nSecond line +mtg=x"
+
+	assert.Equal(t, "Meeting +meeting +client=acme\nSecond line +meeting=x", RenameTag(note, "mtg", "meeting"))
+	assert.Equal(t, note, RenameTag(note, "foo", "bar"), "Note without tag should not change")
+
+	assert.Equal(t, "Meeting +mtg +client=foo\nSecond line +mtg=x", SetTag(note, "client", "foo"))
+	assert.Equal(t, note+" +billable", SetTag(note, "billable", ""))
+	assert.Equal(t, "+rate=90", SetTag("", "rate", "90"))
+
+	assert.Equal(t, "Meeting +client=acme\nSecond line", RemoveTag(note, "mtg"))
+	assert.Equal(t, "Meeting  +mtg", RemoveTag("Meeting  +mtg", "foo"), "Spacing should be kept without removal")
+	assert.Equal(t, "", RemoveTag("+mtg", "mtg"))
+}
+
+func TestUpdateTags(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+
+	project := NewProject("test", "", "T", []string{"client"}, 0, 15)
+	project.TagSchema = TagSchema{"rate": TagSpec{Type: TagTypeInt}}
+	if err = track.SaveProject(project, false); err != nil {
+		t.Fatal("error saving project")
+	}
+
+	notes := []string{"A +mtg +client=acme", "B +client=acme", "C +client=foo +mtg"}
+	for i, note := range notes {
+		tags, err := ExtractTags(note)
+		if err != nil {
+			t.Fatal("error extracting tags")
+		}
+		record := Record{
+			Project: "test",
+			Start:   util.DateTime(2001, 2, 3, 8+i, 0, 0),
+			End:     util.DateTime(2001, 2, 3, 9+i, 0, 0),
+			Note:    note,
+			Tags:    tags,
+		}
+		if err = track.SaveRecord(&record, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	rename := func(note string) string { return RenameTag(note, "mtg", "meeting") }
+
+	changes, err := track.UpdateTags(FilterFunctions{}, rename, true)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(changes), "Wrong number of changes")
+	assert.Equal(t, "A +mtg +client=acme", changes[0].OldNote)
+	assert.Equal(t, "A +meeting +client=acme", changes[0].Record.Note)
+	assert.Equal(t, map[string]string{"meeting": "", "client": "acme"}, changes[0].Record.Tags)
+
+	record, err := track.LoadRecord(util.DateTime(2001, 2, 3, 8, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "A +mtg +client=acme", record.Note, "Dry run should not change records")
+
+	_, err = track.UpdateTags(FilterFunctions{}, rename, false)
+	assert.Nil(t, err)
+	record, err = track.LoadRecord(util.DateTime(2001, 2, 3, 8, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "A +meeting +client=acme", record.Note, "Record not changed")
+
+	_, err = track.UpdateTags(FilterFunctions{}, func(note string) string { return RemoveTag(note, "client") }, false)
+	assert.NotNil(t, err, "Expected error for removing a required tag")
+	_, err = track.UpdateTags(FilterFunctions{}, func(note string) string { return SetTag(note, "rate", "high") }, false)
+	assert.NotNil(t, err, "Expected error for tag violating the schema")
+
+	record, err = track.LoadRecord(util.DateTime(2001, 2, 3, 9, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "B +client=acme", record.Note, "Records should not change on error")
+}
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+// Tag value types
+const (
+	// TagTypeString accepts any value, or values matching the pattern
+	TagTypeString = "string"
+	// TagTypeInt accepts integer values
+	TagTypeInt = "int"
+	// TagTypeDuration accepts durations, like 1h30m or 2d
+	TagTypeDuration = "duration"
+	// TagTypeDate accepts dates in the format yyyy-mm-dd
+	TagTypeDate = "date"
+)
+
+// TagSpec declares the type, allowed values and default value of a tag
+type TagSpec struct {
+	// Value type: string, int, duration or date. Defaults to string
+	Type string `yaml:"type,omitempty"`
+	// Regular expression for string values. Must match the entire value
+	Pattern string `yaml:"pattern,omitempty"`
+	// Allowed values. Any value of the type if empty
+	Values []string `yaml:"values,omitempty"`
+	// Default value, added to new records that don't have the tag
+	Default string `yaml:"default,omitempty"`
+
+	// Compiled pattern, set by Validate
+	pattern *regexp.Regexp
+}
+
+// TagSchema maps tag names to their specification
+type TagSchema map[string]TagSpec
+
+// Validate checks the spec for consistency, and compiles its pattern
+func (s *TagSpec) Validate() error {
+	switch s.Type {
+	case "", TagTypeString, TagTypeInt, TagTypeDuration, TagTypeDate:
+	default:
+		return fmt.Errorf("invalid tag type '%s'", s.Type)
+	}
+	if s.Pattern != "" {
+		if s.Type != "" && s.Type != TagTypeString {
+			return fmt.Errorf("pattern is only allowed for tag type '%s'", TagTypeString)
+		}
+	}
+	if err := s.compile(); err != nil {
+		return err
+	}
+	for _, v := range s.Values {
+		if err := s.checkType(v); err != nil {
+			return err
+		}
+	}
+	if s.Default != "" {
+		if err := s.Check(s.Default); err != nil {
+			return fmt.Errorf("invalid default: %s", err)
+		}
+	}
+	return nil
+}
+
+// Check checks a tag value against the spec
+func (s *TagSpec) Check(value string) error {
+	if err := s.checkType(value); err != nil {
+		return err
+	}
+	if len(s.Values) > 0 {
+		for _, v := range s.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("value '%s' not allowed. Allowed values: %s", value, strings.Join(s.Values, ", "))
+	}
+	return nil
+}
+
+func (s *TagSpec) checkType(value string) error {
+	switch s.Type {
+	case TagTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("value '%s' is not an integer", value)
+		}
+	case TagTypeDuration:
+		if _, err := util.ParseDuration(value); err != nil {
+			return fmt.Errorf("value '%s' is not a duration", value)
+		}
+	case TagTypeDate:
+		if _, err := time.ParseInLocation(util.DateFormat, value, time.Local); err != nil {
+			return fmt.Errorf("value '%s' is not a date in format %s", value, util.DateFormat)
+		}
+	default:
+		if s.Pattern == "" {
+			break
+		}
+		// Specs that were not validated, like those created in code, have no compiled pattern yet
+		if s.pattern == nil {
+			if err := s.compile(); err != nil {
+				return err
+			}
+		}
+		if !s.pattern.MatchString(value) {
+			return fmt.Errorf("value '%s' does not match pattern '%s'", value, s.Pattern)
+		}
+	}
+	return nil
+}
+
+// compile compiles the pattern of the spec, to match entire values
+func (s *TagSpec) compile() error {
+	s.pattern = nil
+	if s.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile("^(?:" + s.Pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid pattern '%s': %s", s.Pattern, err)
+	}
+	s.pattern = re
+	return nil
+}
+
+// Validate checks all specs of the schema for consistency, and compiles their patterns
+func (s TagSchema) Validate() error {
+	for _, name := range s.names() {
+		spec := s[name]
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("tag '%s': %s", name, err)
+		}
+		s[name] = spec
+	}
+	return nil
+}
+
+// Check checks the given tags against the schema.
+// Tags that are not in the schema are not checked.
+func (s TagSchema) Check(tags map[string]string) error {
+	for _, name := range s.names() {
+		value, ok := tags[name]
+		if !ok {
+			continue
+		}
+		spec := s[name]
+		if err := spec.Check(value); err != nil {
+			return fmt.Errorf("invalid tag '%s': %s", name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyDefaults adds tags with default values that are missing in tags,
+// and appends them to the note. Returns the new note.
+func (s TagSchema) ApplyDefaults(note string, tags map[string]string) string {
+	for _, name := range s.names() {
+		spec := s[name]
+		if spec.Default == "" {
+			continue
+		}
+		if _, ok := tags[name]; ok {
+			continue
+		}
+		tags[name] = spec.Default
+		if note != "" {
+			note += " "
+		}
+		note += fmt.Sprintf("%s%s=%s", TagPrefix, name, spec.Default)
+	}
+	return note
+}
+
+func (s TagSchema) names() []string {
+	names := maps.Keys(s)
+	sort.Strings(names)
+	return names
+}
+
+// TagSchemaFor returns the tag schema for a project.
+// Specs are inherited from ancestors, and overwritten by specs for the same tag further down the tree.
+func (t *Track) TagSchemaFor(project string, tree *ProjectTree) TagSchema {
+	schema := TagSchema{}
+	node, ok := tree.Nodes[project]
+	if !ok {
+		return schema
+	}
+	ancestors := []*ProjectNode{}
+	for node != nil {
+		ancestors = append(ancestors, node)
+		node = node.Parent
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		for name, spec := range ancestors[i].Value.TagSchema {
+			schema[name] = spec
+		}
+	}
+	return schema
+}
+
+// LoadTagSchema loads all projects and returns the tag schema for a project
+func (t *Track) LoadTagSchema(project string) (TagSchema, error) {
+	projects, err := t.LoadAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := t.ToProjectTree(projects)
+	if err != nil {
+		return nil, err
+	}
+	return t.TagSchemaFor(project, tree), nil
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSpec(t *testing.T) {
+	tt := []struct {
+		title    string
+		spec     TagSpec
+		value    string
+		expError bool
+	}{
+		{title: "any string", spec: TagSpec{}, value: "foo"},
+		{title: "pattern match", spec: TagSpec{Pattern: "[A-Z]+-[0-9]+"}, value: "ABC-12"},
+		{title: "pattern partial match", spec: TagSpec{Pattern: "[A-Z]+-[0-9]+"}, value: "xABC-12", expError: true},
+		{title: "int", spec: TagSpec{Type: TagTypeInt}, value: "12"},
+		{title: "no int", spec: TagSpec{Type: TagTypeInt}, value: "1.5", expError: true},
+		{title: "duration", spec: TagSpec{Type: TagTypeDuration}, value: "1d2h"},
+		{title: "no duration", spec: TagSpec{Type: TagTypeDuration}, value: "2 hours", expError: true},
+		{title: "date", spec: TagSpec{Type: TagTypeDate}, value: "2023-03-14"},
+		{title: "no date", spec: TagSpec{Type: TagTypeDate}, value: "14.03.2023", expError: true},
+		{title: "allowed value", spec: TagSpec{Values: []string{"a", "b"}}, value: "b"},
+		{title: "disallowed value", spec: TagSpec{Values: []string{"a", "b"}}, value: "c", expError: true},
+		{title: "empty value", spec: TagSpec{Values: []string{"a", "b"}}, value: "", expError: true},
+	}
+
+	for _, test := range tt {
+		err := test.spec.Check(test.value)
+		if test.expError {
+			assert.NotNil(t, err, "Expected error in %s", test.title)
+		} else {
+			assert.Nil(t, err, "Unexpected error in %s", test.title)
+		}
+	}
+
+	assert.NotNil(t, (&TagSpec{Type: "float"}).Validate(), "Expected error for invalid type")
+	assert.NotNil(t, (&TagSpec{Type: TagTypeInt, Pattern: "[0-9]"}).Validate(), "Expected error for pattern with int type")
+	assert.NotNil(t, (&TagSpec{Pattern: "[a-"}).Validate(), "Expected error for invalid pattern")
+	assert.NotNil(t, (&TagSpec{Type: TagTypeInt, Values: []string{"1", "x"}}).Validate(), "Expected error for invalid value")
+	assert.NotNil(t, (&TagSpec{Values: []string{"a", "b"}, Default: "c"}).Validate(), "Expected error for invalid default")
+	assert.Nil(t, (&TagSpec{Type: TagTypeInt, Values: []string{"1", "2"}, Default: "1"}).Validate(), "Unexpected error")
+
+	assert.NotNil(t, (&TagSpec{Pattern: "[a-"}).Check("a"), "Expected error instead of panic for invalid pattern")
+
+	schema := TagSchema{"ticket": TagSpec{Pattern: "[A-Z]+-[0-9]+"}}
+	assert.Nil(t, schema.Validate())
+	assert.NotNil(t, schema["ticket"].pattern, "Pattern should be compiled on validation")
+	assert.NotNil(t, TagSchema{"ticket": TagSpec{Pattern: "[a-"}}.Validate(), "Expected error for invalid pattern")
+}
+
+func TestTagSchema(t *testing.T) {
+	schema := TagSchema{
+		"activity": TagSpec{Values: []string{"coding", "meeting"}, Default: "coding"},
+		"effort":   TagSpec{Type: TagTypeInt},
+	}
+
+	assert.Nil(t, schema.Check(map[string]string{"activity": "meeting", "other": "x"}))
+	assert.NotNil(t, schema.Check(map[string]string{"activity": "meting"}))
+
+	tags := map[string]string{"effort": "3"}
+	note := schema.ApplyDefaults("Note +effort=3", tags)
+	assert.Equal(t, "Note +effort=3 +activity=coding", note, "Wrong note with defaults")
+	assert.Equal(t, map[string]string{"effort": "3", "activity": "coding"}, tags, "Wrong tags with defaults")
+
+	tags = map[string]string{"activity": "meeting"}
+	note = schema.ApplyDefaults("+activity=meeting", tags)
+	assert.Equal(t, "+activity=meeting", note, "Default should not overwrite existing tag")
+}
+
+func TestTagSchemaFor(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+
+	parent := NewProject("parent", "", "P", []string{}, 0, 15)
+	parent.TagSchema = TagSchema{
+		"activity": TagSpec{Values: []string{"coding", "meeting"}},
+		"ticket":   TagSpec{Pattern: "[0-9]+"},
+	}
+	child := NewProject("child", "parent", "C", []string{}, 0, 15)
+	child.TagSchema = TagSchema{
+		"activity": TagSpec{Values: []string{"writing"}, Default: "writing"},
+	}
+	for _, p := range []Project{parent, child} {
+		if err = track.SaveProject(p, false); err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+
+	schema, err := track.LoadTagSchema("child")
+	assert.Nil(t, err)
+	expected := TagSchema{
+		"activity": TagSpec{Values: []string{"writing"}, Default: "writing"},
+		"ticket":   TagSpec{Pattern: "[0-9]+"},
+	}
+	assert.Nil(t, expected.Validate())
+	assert.Equal(t, expected, schema, "Wrong inherited schema")
+
+	record, err := track.StartRecord(&child, "Note +ticket=12", map[string]string{"ticket": "12"}, util.Date(2001, 2, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, "Note +ticket=12 +activity=writing", record.Note, "Default tag not added to note")
+
+	_, err = track.StartRecord(&child, "Note +ticket=T12", map[string]string{"ticket": "T12"}, util.Date(2001, 2, 4))
+	assert.NotNil(t, err, "Expected error for invalid inherited tag")
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mlange-42/track/util"
+)
+
+// TagValueSeparator separates the levels of hierarchical tag values, like in "+area=backend/db"
+const TagValueSeparator = "/"
+
+// TagNode is a node in a tree of tags and their hierarchical values
+type TagNode struct {
+	// Unique name of the node: the tag, or "tag=value" for (partial) values
+	Name string
+	// Label for display: the tag, or the last level of the value
+	Label string
+}
+
+// GetName implements the Named interface required for the MapTree
+func (n TagNode) GetName() string {
+	return n.Name
+}
+
+// TagTree is a tree of tags and their hierarchical values
+type TagTree = util.MapTree[TagNode]
+
+// TagTreeNode is a node of a TagTree
+type TagTreeNode = util.MapNode[TagNode]
+
+// TagTree creates a tree of tags and their hierarchical values, for the given tags or for all tags if none are given.
+// Returns the tree and statistics per node name, aggregated from values to parent values and tags.
+func (r *Reporter) TagTree(tags []string) (*TagTree, map[string]TagValueStats) {
+	tree := util.NewTree(TagNode{Name: "", Label: fmt.Sprintf(RootPattern, "tags")})
+	stats := map[string]TagValueStats{}
+
+	for tag, tagStats := range r.TagStats(tags) {
+		tagNode := addTagNode(tree, tree.Root, tag, tag)
+		for value, valueStats := range tagStats.Values {
+			node := tagNode
+			path := ""
+			for _, level := range strings.Split(value, TagValueSeparator) {
+				if level == "" {
+					continue
+				}
+				if path != "" {
+					path += TagValueSeparator
+				}
+				path += level
+				node = addTagNode(tree, node, fmt.Sprintf("%s=%s", tag, path), level)
+			}
+			st := stats[node.Value.Name]
+			st.Count += valueStats.Count
+			st.Work += valueStats.Work
+			st.Pause += valueStats.Pause
+			stats[node.Value.Name] = st
+		}
+	}
+
+	util.Aggregate(tree, stats, TagValueStats{}, func(a, b TagValueStats) TagValueStats {
+		return TagValueStats{Count: a.Count + b.Count, Work: a.Work + b.Work, Pause: a.Pause + b.Pause}
+	})
+	return tree, stats
+}
+
+// addTagNode adds a node to a tag tree, or returns the existing node of the same name
+func addTagNode(tree *TagTree, parent *TagTreeNode, name, label string) *TagTreeNode {
+	if node, ok := tree.Nodes[name]; ok {
+		return node
+	}
+	node, _ := tree.Add(parent, TagNode{Name: name, Label: label})
+	return node
+}
+
+// ApplyTagAliases replaces aliases in the tag names of a record by their targets.
+// If a record has both an alias and its target, the value of the target is kept.
+func ApplyTagAliases(tags map[string]string, aliases map[string]string) {
+	for alias, target := range aliases {
+		value, ok := tags[alias]
+		if !ok {
+			continue
+		}
+		delete(tags, alias)
+		if _, ok := tags[target]; !ok {
+			tags[target] = value
+		}
+	}
+}
+
+// ResolveTagAlias returns the target of a tag alias, or the name itself if it is not an alias
+func ResolveTagAlias(name string, aliases map[string]string) string {
+	if target, ok := aliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+// checkTagAliases checks that tag aliases are not empty, and don't point to other aliases
+func checkTagAliases(aliases map[string]string) error {
+	for alias, target := range aliases {
+		if alias == "" || target == "" {
+			return fmt.Errorf("empty tag alias or target ('%s' -> '%s')", alias, target)
+		}
+		if _, ok := aliases[target]; ok {
+			return fmt.Errorf("target of tag alias '%s' is an alias itself ('%s')", alias, target)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagTree(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+	track.Config.TagAliases = map[string]string{"mtg": "meeting"}
+
+	project := NewProject("test", "", "T", []string{}, 0, 15)
+	if err = track.SaveProject(project, false); err != nil {
+		t.Fatal("error saving project")
+	}
+
+	notes := []string{"+area=backend/db +mtg", "+area=backend/api", "+area=frontend +meeting", "+area=backend"}
+	for i, note := range notes {
+		tags, err := ExtractTags(note)
+		if err != nil {
+			t.Fatal("error extracting tags")
+		}
+		record := Record{
+			Project: "test",
+			Start:   util.DateTime(2001, 2, 3, 8+i, 0, 0),
+			End:     util.DateTime(2001, 2, 3, 9+i, 0, 0),
+			Note:    note,
+			Tags:    tags,
+		}
+		if err = track.SaveRecord(&record, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	record, err := track.LoadRecord(util.DateTime(2001, 2, 3, 8, 0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"area": "backend/db", "meeting": ""}, record.Tags, "Tag alias not applied")
+
+	reporter, err := NewReporter(&track, []string{}, FilterFunctions{}, false, util.NoTime, util.NoTime)
+	if err != nil {
+		t.Fatal("error creating reporter")
+	}
+
+	tree, stats := reporter.TagTree(nil)
+	assert.Equal(t, 2, len(tree.Root.Children), "Wrong number of tags")
+	assert.Equal(t, "db", tree.Nodes["area=backend/db"].Value.Label, "Wrong label")
+	assert.Equal(t, tree.Nodes["area=backend"], tree.Nodes["area=backend/db"].Parent, "Wrong parent")
+
+	assert.Equal(t, TagValueStats{Count: 4, Work: 4 * time.Hour}, stats["area"], "Wrong tag stats")
+	assert.Equal(t, TagValueStats{Count: 3, Work: 3 * time.Hour}, stats["area=backend"], "Wrong aggregated value stats")
+	assert.Equal(t, TagValueStats{Count: 1, Work: time.Hour}, stats["area=backend/api"], "Wrong value stats")
+	assert.Equal(t, TagValueStats{Count: 2, Work: 2 * time.Hour}, stats["meeting"], "Wrong stats for aliased tag")
+
+	tree, _ = reporter.TagTree([]string{"meeting"})
+	assert.Equal(t, 1, len(tree.Root.Children), "Wrong number of tags")
+}
+
+func TestTagAliases(t *testing.T) {
+	tags := map[string]string{"mtg": "a", "meeting": "b", "other": ""}
+	ApplyTagAliases(tags, map[string]string{"mtg": "meeting", "foo": "bar"})
+	assert.Equal(t, map[string]string{"meeting": "b", "other": ""}, tags, "Target value should be kept")
+
+	assert.Equal(t, "meeting", ResolveTagAlias("mtg", map[string]string{"mtg": "meeting"}), "Wrong alias target")
+	assert.Equal(t, "dev", ResolveTagAlias("dev", map[string]string{"mtg": "meeting"}), "Non-alias should be unchanged")
+
+	assert.Nil(t, checkTagAliases(map[string]string{"mtg": "meeting", "dev": "coding"}))
+	assert.NotNil(t, checkTagAliases(map[string]string{"mtg": "meeting", "meeting": "mt"}), "Expected error for alias chain")
+	assert.NotNil(t, checkTagAliases(map[string]string{"mtg": ""}), "Expected error for empty target")
+}
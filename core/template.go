@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mlange-42/track/util"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	templatesDirName  = "templates"
+	templateExtension = ".yml"
+)
+
+// TemplateNameParameter is the template parameter that is replaced by the name given for the instance
+const TemplateNameParameter = "name"
+
+var unresolvedParameter = regexp.MustCompile(`\{[\w-]+\}`)
+
+// ProjectTemplate is a template for creating a subtree of projects
+type ProjectTemplate struct {
+	// Projects of the template.
+	// Projects without a parent are added to the parent given when instantiating the template.
+	Projects []Project `yaml:"projects"`
+}
+
+// TemplatesDir returns the directory of project templates
+func (t *Track) TemplatesDir() string {
+	return filepath.Join(t.RootDir, templatesDirName)
+}
+
+// TemplatePath returns the full path for a project template
+func (t *Track) TemplatePath(name string) string {
+	return filepath.Join(t.TemplatesDir(), util.Sanitize(name)+templateExtension)
+}
+
+// AllTemplates returns the names of all project templates
+func (t *Track) AllTemplates() ([]string, error) {
+	if !util.DirExists(t.TemplatesDir()) {
+		return []string{}, nil
+	}
+	files, err := os.ReadDir(t.TemplatesDir())
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != templateExtension {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(file.Name(), templateExtension))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadProjectTemplate loads a project template.
+// Placeholders like {name} in the template are replaced by the given parameters, using util.Format.
+// Fails if any placeholders are left.
+func (t *Track) LoadProjectTemplate(name string, params map[string]string) (ProjectTemplate, error) {
+	file, err := os.ReadFile(t.TemplatePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ProjectTemplate{}, fmt.Errorf("no template named '%s'", name)
+		}
+		return ProjectTemplate{}, err
+	}
+
+	text := util.Format(string(file), params)
+	if missing := unresolvedParameter.FindAllString(text, -1); len(missing) > 0 {
+		return ProjectTemplate{}, fmt.Errorf("missing template parameters: %s", strings.Join(util.Unique(missing), ", "))
+	}
+
+	var template ProjectTemplate
+	if err := yaml.Unmarshal([]byte(text), &template); err != nil {
+		return ProjectTemplate{}, fmt.Errorf("template '%s': %s", name, err)
+	}
+	if len(template.Projects) == 0 {
+		return ProjectTemplate{}, fmt.Errorf("template '%s' has no projects", name)
+	}
+	return template, nil
+}
+
+// CreateFromTemplate creates the projects of a template.
+// Projects of the template without a parent get the given parent.
+// All projects are checked before any project is saved.
+func (t *Track) CreateFromTemplate(template ProjectTemplate, parent string) ([]Project, error) {
+	if parent != "" && !t.ProjectExists(parent) {
+		return nil, fmt.Errorf("project '%s' does not exist", parent)
+	}
+
+	names := map[string]bool{}
+	for _, p := range template.Projects {
+		if p.Name == "" {
+			return nil, fmt.Errorf("template contains a project without name")
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("duplicate project '%s' in template", p.Name)
+		}
+		if t.ProjectExists(p.Name) {
+			return nil, fmt.Errorf("project '%s' already exists", p.Name)
+		}
+		names[p.Name] = true
+	}
+
+	projects := make([]Project, len(template.Projects))
+	for i, p := range template.Projects {
+		if p.Parent == "" {
+			p.Parent = parent
+		} else if !names[p.Parent] {
+			return nil, fmt.Errorf("parent '%s' of project '%s' is not in the template", p.Parent, p.Name)
+		}
+		if p.Symbol == "" {
+			p.Symbol = string([]rune(p.Name)[0])
+		}
+		projects[i] = p
+	}
+
+	for i, p := range projects {
+		if err := t.SaveProject(p, false); err != nil {
+			return projects[:i], err
+		}
+	}
+	return projects, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectTemplate(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	templates, err := track.AllTemplates()
+	assert.Nil(t, err)
+	assert.Empty(t, templates)
+
+	assert.Nil(t, os.MkdirAll(track.TemplatesDir(), os.ModePerm))
+	assert.Nil(t, os.WriteFile(track.TemplatePath("client"), []byte(`projects:
+  - name: "{name}"
+    requiredTags: [client]
+  - name: "{name}/dev"
+    parent: "{name}"
+    symbol: D
+    tagSchema:
+      rate:
+        default: "{rate}"
+`), 0600))
+
+	templates, err = track.AllTemplates()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"client"}, templates)
+
+	ws, err := track.AllWorkspaces()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default"}, ws, "Templates directory should not be a workspace")
+	assert.NotNil(t, track.CreateWorkspace("templates"), "Workspace name should be reserved")
+
+	_, err = track.LoadProjectTemplate("missing", map[string]string{})
+	assert.NotNil(t, err)
+	_, err = track.LoadProjectTemplate("client", map[string]string{"name": "acme"})
+	assert.NotNil(t, err, "Should fail with missing parameters")
+
+	tmpl, err := track.LoadProjectTemplate("client", map[string]string{"name": "acme", "rate": "90"})
+	assert.Nil(t, err)
+
+	_, err = track.CreateFromTemplate(tmpl, "clients")
+	assert.NotNil(t, err, "Should fail for missing parent")
+
+	assert.Nil(t, track.SaveProject(NewProject("clients", "", "c", []string{}, 0, 0), false))
+	projects, err := track.CreateFromTemplate(tmpl, "clients")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(projects))
+
+	acme, err := track.LoadProject("acme")
+	assert.Nil(t, err)
+	assert.Equal(t, "clients", acme.Parent)
+	assert.Equal(t, "a", acme.Symbol)
+	assert.Equal(t, []string{"client"}, acme.RequiredTags)
+
+	dev, err := track.LoadProject("acme/dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", dev.Parent)
+	assert.Equal(t, "D", dev.Symbol)
+	assert.Equal(t, "90", dev.TagSchema["rate"].Default)
+
+	_, err = track.CreateFromTemplate(tmpl, "clients")
+	assert.NotNil(t, err, "Should fail for existing projects")
+}
@@ -18,7 +18,14 @@ const (
 // Track is a top-level track instance
 type Track struct {
 	RootDir string
-	Config  Config
+	// The effective config, with workspace overrides applied
+	Config Config
+	// The global config, as stored in the root directory
+	GlobalConfig Config
+	// Overrides of the current workspace
+	WorkspaceConfig WorkspaceConfig
+	// Workspaces combined for reading projects and records. Only the current workspace if empty.
+	readWorkspaces []string
 }
 
 // NewTrack creates a new Track object
@@ -32,9 +39,12 @@ func NewTrack(root *string) (Track, error) {
 	if err != nil {
 		return track, err
 	}
+	track.GlobalConfig = conf
+	track.createWorkspaceDirs(conf.Workspace)
 
-	track.Config = conf
-	track.createWorkspaceDirs(track.Config.Workspace)
+	if err := track.loadWorkspaceConfig(); err != nil {
+		return track, err
+	}
 
 	return track, nil
 }
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/mlange-42/track/util"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	trashDirName  = "trash"
+	trashInfoFile = "info.yml"
+	trashIDFormat = "20060102-150405"
+)
+
+// Kinds of trash entries
+const (
+	// TrashRecord is the kind of trash entries for a single record
+	TrashRecord = "record"
+	// TrashProject is the kind of trash entries for a project and its records
+	TrashProject = "project"
+)
+
+var (
+	// ErrTrashEntryNotFound is an error for a missing trash entry
+	ErrTrashEntryNotFound = errors.New("trash entry not found")
+)
+
+// trashIDRegex matches trash entry IDs: trashIDFormat, with an optional counter suffix like "-2"
+var trashIDRegex = regexp.MustCompile(`^\d{8}-\d{6}(-\d+)?$`)
+
+// TrashEntry holds the metadata of a deletion.
+// The deleted files are stored next to the metadata, in the entry's directory.
+type TrashEntry struct {
+	// ID of the entry, equal to the name of its directory
+	ID string `yaml:"-"`
+	// Kind of the entry, record or project
+	Kind string `yaml:"kind"`
+	// Display name of the deleted resource
+	Name string `yaml:"name"`
+	// Name of the project the deleted resource belongs to
+	Project string `yaml:"project"`
+	// Reason for the deletion
+	Reason string `yaml:"reason"`
+	// Time of the deletion
+	Deleted time.Time `yaml:"deleted"`
+	// Deleted files, relative to the workspace directory
+	Files []string `yaml:"files"`
+}
+
+// TrashDir returns the trash directory of the current workspace
+func (t *Track) TrashDir() string {
+	return filepath.Join(t.RootDir, t.Workspace(), trashDirName)
+}
+
+// trashEntryDir returns the directory of a trash entry
+func (t *Track) trashEntryDir(id string) string {
+	return filepath.Join(t.TrashDir(), id)
+}
+
+// checkTrashID checks that a trash entry ID has the expected format,
+// so that it can't point outside of the trash directory
+func checkTrashID(id string) error {
+	if !trashIDRegex.MatchString(id) {
+		return fmt.Errorf("invalid trash entry ID '%s'", id)
+	}
+	return nil
+}
+
+// newTrashEntry creates a new trash entry with a unique ID, and its directory
+func (t *Track) newTrashEntry(kind, name, project, reason string) (TrashEntry, error) {
+	now := time.Now()
+	base := now.Format(trashIDFormat)
+	id := base
+	for i := 2; util.DirExists(t.trashEntryDir(id)); i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := util.CreateDir(t.trashEntryDir(id)); err != nil {
+		return TrashEntry{}, err
+	}
+
+	return TrashEntry{
+		ID:      id,
+		Kind:    kind,
+		Name:    name,
+		Project: project,
+		Reason:  reason,
+		Deleted: now,
+		Files:   []string{},
+	}, nil
+}
+
+// moveToTrash moves a file of the current workspace into a trash entry
+func (t *Track) moveToTrash(entry *TrashEntry, path string) error {
+	rel, err := filepath.Rel(t.WorkspaceDir(t.Workspace()), path)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(t.trashEntryDir(entry.ID), rel)
+	if err := util.CreateDir(filepath.Dir(target)); err != nil {
+		return err
+	}
+	if err := os.Rename(path, target); err != nil {
+		return err
+	}
+	entry.Files = append(entry.Files, filepath.ToSlash(rel))
+	return nil
+}
+
+// saveTrashEntry saves the metadata of a trash entry
+func (t *Track) saveTrashEntry(entry *TrashEntry) error {
+	bytes, err := yaml.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.trashEntryDir(entry.ID), trashInfoFile), bytes, 0644)
+}
+
+// LoadTrashEntry loads a trash entry of the current workspace by its ID
+func (t *Track) LoadTrashEntry(id string) (TrashEntry, error) {
+	if err := checkTrashID(id); err != nil {
+		return TrashEntry{}, err
+	}
+	file, err := os.ReadFile(filepath.Join(t.trashEntryDir(id), trashInfoFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return TrashEntry{}, ErrTrashEntryNotFound
+		}
+		return TrashEntry{}, err
+	}
+
+	var entry TrashEntry
+	if err := yaml.Unmarshal(file, &entry); err != nil {
+		return TrashEntry{}, err
+	}
+	entry.ID = id
+
+	return entry, nil
+}
+
+// LoadTrash loads all trash entries of the current workspace, oldest first.
+// Entries that can't be read are skipped, and returned as the second value, one error per entry.
+func (t *Track) LoadTrash() ([]TrashEntry, []error, error) {
+	if !util.DirExists(t.TrashDir()) {
+		return []TrashEntry{}, nil, nil
+	}
+	dirs, err := os.ReadDir(t.TrashDir())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := []TrashEntry{}
+	skipped := []error{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := t.LoadTrashEntry(dir.Name())
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("trash entry '%s': %s", dir.Name(), err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Deleted.Before(entries[j].Deleted)
+	})
+
+	return entries, skipped, nil
+}
+
+// RestoreTrashEntry moves the files of a trash entry back to their original location,
+// and removes the entry from the trash.
+// Fails without changes if any of the files exists already,
+// or if the project of a deleted record does not exist.
+func (t *Track) RestoreTrashEntry(entry *TrashEntry) error {
+	if err := checkTrashID(entry.ID); err != nil {
+		return err
+	}
+	wsDir := t.WorkspaceDir(t.Workspace())
+	for _, file := range entry.Files {
+		if util.FileExists(filepath.Join(wsDir, filepath.FromSlash(file))) {
+			return fmt.Errorf("file '%s' exists already", file)
+		}
+	}
+	if entry.Kind == TrashRecord && !t.ProjectExists(entry.Project) {
+		return fmt.Errorf("project '%s' does not exist", entry.Project)
+	}
+	if entry.Kind == TrashProject {
+		project, err := t.loadProjectFromFile(filepath.Join(t.trashEntryDir(entry.ID), projectsDirName, util.Sanitize(entry.Project)+".yml"))
+		if err != nil {
+			return err
+		}
+		if project.Parent != "" && !t.ProjectExists(project.Parent) {
+			return fmt.Errorf("parent project '%s' does not exist", project.Parent)
+		}
+	}
+
+	for _, file := range entry.Files {
+		target := filepath.Join(wsDir, filepath.FromSlash(file))
+		if err := util.CreateDir(filepath.Dir(target)); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(t.trashEntryDir(entry.ID), filepath.FromSlash(file)), target); err != nil {
+			return err
+		}
+	}
+
+	return os.RemoveAll(t.trashEntryDir(entry.ID))
+}
+
+// DeleteTrashEntry permanently deletes a trash entry and its files
+func (t *Track) DeleteTrashEntry(entry *TrashEntry) error {
+	if err := checkTrashID(entry.ID); err != nil {
+		return err
+	}
+	return os.RemoveAll(t.trashEntryDir(entry.ID))
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrash(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	project := NewProject("test", "", "T", []string{}, 0, 15)
+	err = track.SaveProject(project, false)
+	assert.Nil(t, err, "Error saving project")
+
+	record1 := Record{
+		Project: "test",
+		Start:   util.DateTime(2001, 2, 3, 4, 5, 0),
+		End:     util.DateTime(2001, 2, 3, 5, 5, 0),
+	}
+	record2 := Record{
+		Project: "test",
+		Start:   util.DateTime(2001, 2, 4, 4, 5, 0),
+		End:     util.DateTime(2001, 2, 4, 5, 5, 0),
+	}
+	err = track.SaveRecord(&record1, false)
+	assert.Nil(t, err, "Error saving record")
+	err = track.SaveRecord(&record2, false)
+	assert.Nil(t, err, "Error saving record")
+
+	err = track.DeleteRecord(&record1, "test record")
+	assert.Nil(t, err, "Error deleting record")
+	assert.False(t, util.FileExists(track.RecordPath(record1.Start)), "Record file must not exist")
+
+	entries, _, err := track.LoadTrash()
+	assert.Nil(t, err, "Error loading trash")
+	assert.Equal(t, 1, len(entries), "Wrong number of trash entries")
+	assert.Equal(t, TrashRecord, entries[0].Kind, "Wrong kind of trash entry")
+	assert.Equal(t, "test record", entries[0].Reason, "Wrong reason of trash entry")
+	assert.Equal(t, []string{"records/2001/02/03/04-05.trk"}, entries[0].Files, "Wrong files of trash entry")
+
+	cnt, err := track.DeleteProject(&project, true, "test project", false)
+	assert.Nil(t, err, "Error deleting project")
+	assert.Equal(t, 1, cnt, "Wrong number of deleted records")
+	assert.False(t, track.ProjectExists("test"), "Project must not exist")
+
+	entries, _, err = track.LoadTrash()
+	assert.Nil(t, err, "Error loading trash")
+	assert.Equal(t, 2, len(entries), "Wrong number of trash entries")
+
+	recordEntry, projectEntry := entries[0], entries[1]
+	assert.Equal(t, TrashProject, projectEntry.Kind, "Wrong kind of trash entry")
+	assert.Equal(t, 2, len(projectEntry.Files), "Wrong number of files in trash entry")
+
+	err = track.RestoreTrashEntry(&recordEntry)
+	assert.NotNil(t, err, "Restoring record without project should fail")
+
+	err = track.RestoreTrashEntry(&projectEntry)
+	assert.Nil(t, err, "Error restoring project")
+	assert.True(t, track.ProjectExists("test"), "Project must exist")
+	_, err = track.LoadRecord(record2.Start)
+	assert.Nil(t, err, "Error loading restored record")
+
+	err = track.RestoreTrashEntry(&recordEntry)
+	assert.Nil(t, err, "Error restoring record")
+	_, err = track.LoadRecord(record1.Start)
+	assert.Nil(t, err, "Error loading restored record")
+
+	entries, _, err = track.LoadTrash()
+	assert.Nil(t, err, "Error loading trash")
+	assert.Equal(t, 0, len(entries), "Trash should be empty")
+
+	_, err = track.LoadTrashEntry("20010203-040506")
+	assert.Equal(t, ErrTrashEntryNotFound, err, "Expected not found error")
+
+	for _, id := range []string{"../projects", "20010203-040506/..", "missing", ""} {
+		_, err = track.LoadTrashEntry(id)
+		assert.NotNil(t, err, "Expected error for invalid ID '%s'", id)
+		assert.NotEqual(t, ErrTrashEntryNotFound, err, "Expected invalid ID error for '%s'", id)
+	}
+	assert.NotNil(t, track.DeleteTrashEntry(&TrashEntry{ID: ".."}), "Expected error for invalid ID")
+	assert.NotNil(t, track.RestoreTrashEntry(&TrashEntry{ID: ".."}), "Expected error for invalid ID")
+
+	corrupt := track.trashEntryDir("20010203-040506-2")
+	assert.Nil(t, os.MkdirAll(corrupt, os.ModePerm))
+	assert.Nil(t, os.WriteFile(filepath.Join(corrupt, trashInfoFile), []byte("deleted: ["), 0644))
+	assert.Nil(t, os.MkdirAll(track.trashEntryDir("other"), os.ModePerm))
+
+	entries, skipped, err := track.LoadTrash()
+	assert.Nil(t, err, "Corrupt trash entries should be skipped")
+	assert.Equal(t, 0, len(entries), "Corrupt trash entry should be skipped")
+	assert.Equal(t, 2, len(skipped), "Expected errors for skipped entries")
+}
@@ -3,12 +3,23 @@ package core
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/mlange-42/track/util"
 )
 
+// WorkspaceSeparator separates workspace and project in namespaced project names, like "work/my-project"
+const WorkspaceSeparator = "/"
+
+// AllWorkspacesLabel is the label of the root of a project tree combining multiple workspaces
+const AllWorkspacesLabel = "<workspaces>"
+
 // CreateWorkspace creates a new workspace
 func (t *Track) CreateWorkspace(name string) error {
+	if err := checkWorkspaceName(name); err != nil {
+		return err
+	}
 	if util.DirExists(t.WorkspaceDir(name)) {
 		return fmt.Errorf("workspace '%s' already exists", name)
 	}
@@ -16,6 +27,163 @@ func (t *Track) CreateWorkspace(name string) error {
 	return nil
 }
 
+// CloneWorkspace creates a new workspace from an existing one.
+// Copies all projects and the workspace config, and all records if projectsOnly is false.
+// Returns the number of copied projects and records.
+func (t *Track) CloneWorkspace(name, from string, projectsOnly bool) (int, int, error) {
+	if !t.WorkspaceExists(from) {
+		return 0, 0, fmt.Errorf("workspace '%s' does not exist", from)
+	}
+	if err := t.CreateWorkspace(name); err != nil {
+		return 0, 0, err
+	}
+
+	projects, err := util.CopyDir(t.workspaceProjectsDir(from), t.workspaceProjectsDir(name))
+	if err != nil {
+		return 0, 0, err
+	}
+	records := 0
+	if !projectsOnly {
+		records, err = util.CopyDir(t.workspaceRecordsDir(from), t.workspaceRecordsDir(name))
+		if err != nil {
+			return projects, 0, err
+		}
+	}
+
+	if util.FileExists(t.WorkspaceConfigPath(from)) {
+		conf, err := LoadWorkspaceConfig(t.WorkspaceConfigPath(from))
+		if err != nil {
+			return projects, records, err
+		}
+		conf.Archived = false
+		if err := conf.Save(t.WorkspaceConfigPath(name)); err != nil {
+			return projects, records, err
+		}
+	}
+
+	return projects, records, nil
+}
+
+// RenameWorkspace renames a workspace. Also renames the current workspace if required.
+func (t *Track) RenameWorkspace(oldName, newName string) error {
+	if !t.WorkspaceExists(oldName) {
+		return fmt.Errorf("workspace '%s' does not exist", oldName)
+	}
+	if err := checkWorkspaceName(newName); err != nil {
+		return err
+	}
+	if util.DirExists(t.WorkspaceDir(newName)) {
+		return fmt.Errorf("workspace '%s' already exists", newName)
+	}
+
+	if err := os.Rename(t.WorkspaceDir(oldName), t.WorkspaceDir(newName)); err != nil {
+		return err
+	}
+	if t.GlobalConfig.Workspace != oldName {
+		return nil
+	}
+
+	t.GlobalConfig.Workspace = newName
+	t.Config.Workspace = newName
+	if err := t.GlobalConfig.Save(t.ConfigPath()); err != nil {
+		// Try to restore a consistent state
+		if e := os.Rename(t.WorkspaceDir(newName), t.WorkspaceDir(oldName)); e == nil {
+			t.GlobalConfig.Workspace = oldName
+			t.Config.Workspace = oldName
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteWorkspace permanently deletes a workspace with all its projects, records and trash.
+// The current workspace can't be deleted.
+// Returns the number of projects and records in the workspace.
+func (t *Track) DeleteWorkspace(name string, dryRun bool) (int, int, error) {
+	if !t.WorkspaceExists(name) {
+		return 0, 0, fmt.Errorf("workspace '%s' does not exist", name)
+	}
+	if t.Workspace() == name {
+		return 0, 0, fmt.Errorf("can't delete the current workspace '%s'", name)
+	}
+
+	view, err := t.workspaceView(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	projects, err := view.LoadAllProjects()
+	if err != nil {
+		return 0, 0, err
+	}
+	records, err := view.LoadAllRecords()
+	if err != nil {
+		return 0, 0, err
+	}
+	if dryRun {
+		return len(projects), len(records), nil
+	}
+
+	return len(projects), len(records), os.RemoveAll(t.WorkspaceDir(name))
+}
+
+// ArchiveWorkspace archives or un-archives a workspace.
+// Archived workspaces are excluded from listings and from combining workspaces.
+// The current workspace can't be archived.
+func (t *Track) ArchiveWorkspace(name string, archived bool) error {
+	if !t.WorkspaceExists(name) {
+		return fmt.Errorf("workspace '%s' does not exist", name)
+	}
+	if archived && t.Workspace() == name {
+		return fmt.Errorf("can't archive the current workspace '%s'", name)
+	}
+	path := t.WorkspaceConfigPath(name)
+	conf, err := LoadWorkspaceConfig(path)
+	if err != nil {
+		return err
+	}
+	conf.Archived = archived
+	return conf.Save(path)
+}
+
+// IsWorkspaceArchived returns whether a workspace is archived
+func (t *Track) IsWorkspaceArchived(name string) (bool, error) {
+	conf, err := LoadWorkspaceConfig(t.WorkspaceConfigPath(name))
+	if err != nil {
+		return false, err
+	}
+	return conf.Archived, nil
+}
+
+// ActiveWorkspaces returns a slice of all workspaces that are not archived
+func (t *Track) ActiveWorkspaces() ([]string, error) {
+	all, err := t.AllWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, ws := range all {
+		archived, err := t.IsWorkspaceArchived(ws)
+		if err != nil {
+			return nil, err
+		}
+		if !archived {
+			result = append(result, ws)
+		}
+	}
+	return result, nil
+}
+
+// checkWorkspaceName checks that a workspace name is not empty and can be used as directory name
+func checkWorkspaceName(name string) error {
+	if name == "" || util.Sanitize(name) != name || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid workspace name '%s'", name)
+	}
+	if name == templatesDirName {
+		return fmt.Errorf("workspace name '%s' is reserved", name)
+	}
+	return nil
+}
+
 // WorkspaceExists returns whether a workspace exists
 func (t *Track) WorkspaceExists(name string) bool {
 	return util.DirExists(t.WorkspaceDir(name))
@@ -35,8 +203,14 @@ func (t *Track) SwitchWorkspace(name string) error {
 	}
 	t.createWorkspaceDirs(name)
 
-	t.Config.Workspace = name
-	err = t.Config.Save(t.ConfigPath())
+	prevWorkspace := t.GlobalConfig.Workspace
+	t.GlobalConfig.Workspace = name
+	if err = t.loadWorkspaceConfig(); err != nil {
+		t.GlobalConfig.Workspace = prevWorkspace
+		return err
+	}
+
+	err = t.GlobalConfig.Save(t.ConfigPath())
 	if err != nil {
 		return err
 	}
@@ -58,7 +232,7 @@ func (t *Track) AllWorkspaces() ([]string, error) {
 	}
 	result := []string{}
 	for _, f := range dirs {
-		if !f.IsDir() {
+		if !f.IsDir() || f.Name() == templatesDirName {
 			continue
 		}
 		result = append(result, f.Name())
@@ -70,3 +244,127 @@ func (t *Track) AllWorkspaces() ([]string, error) {
 func (t *Track) WorkspaceLabel() string {
 	return fmt.Sprintf(RootPattern, t.Config.Workspace)
 }
+
+// ReadWorkspaces sets workspaces to combine when loading projects and records, for reports and listings.
+// Project names are namespaced like "<ws>/project", and the project tree has a node per workspace.
+// Without workspaces, only the current workspace is read, without namespaces.
+//
+// Must not be used with commands that change projects or records.
+func (t *Track) ReadWorkspaces(workspaces []string) error {
+	for _, ws := range workspaces {
+		if !t.WorkspaceExists(ws) {
+			return fmt.Errorf("workspace '%s' does not exist", ws)
+		}
+		archived, err := t.IsWorkspaceArchived(ws)
+		if err != nil {
+			return err
+		}
+		if archived {
+			return fmt.Errorf("workspace '%s' is archived", ws)
+		}
+	}
+	t.readWorkspaces = workspaces
+	return nil
+}
+
+// IsMultiWorkspace returns whether projects and records are read from multiple workspaces
+func (t *Track) IsMultiWorkspace() bool {
+	return len(t.readWorkspaces) > 0
+}
+
+// NamespaceProject returns the namespaced name of a project in a workspace, like "<ws>/project"
+func NamespaceProject(workspace, project string) string {
+	return workspace + WorkspaceSeparator + project
+}
+
+// SplitNamespace splits a namespaced project name into workspace and project
+func SplitNamespace(name string) (string, string) {
+	ws, project, ok := strings.Cut(name, WorkspaceSeparator)
+	if !ok {
+		return "", name
+	}
+	return ws, project
+}
+
+// workspaceView returns a copy of the Track that reads from another workspace,
+// with the effective config of that workspace
+func (t *Track) workspaceView(ws string) (*Track, error) {
+	wsConf, conf, err := t.workspaceConfig(ws)
+	if err != nil {
+		return nil, err
+	}
+	view := *t
+	view.WorkspaceConfig = wsConf
+	view.Config = conf
+	view.readWorkspaces = nil
+	return &view, nil
+}
+
+// loadAllProjectsMulti loads all projects of all read workspaces, with namespaced names
+func (t *Track) loadAllProjectsMulti() (map[string]Project, error) {
+	projects := map[string]Project{}
+	for _, ws := range t.readWorkspaces {
+		view, err := t.workspaceView(ws)
+		if err != nil {
+			return nil, err
+		}
+		wsProjects, err := view.LoadAllProjects()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range wsProjects {
+			p.Name = NamespaceProject(ws, p.Name)
+			if p.Parent != "" {
+				p.Parent = NamespaceProject(ws, p.Parent)
+			}
+			projects[p.Name] = p
+		}
+	}
+	return projects, nil
+}
+
+// allRecordsMulti loads the records of all read workspaces, with namespaced projects, in chronological order
+func (t *Track) allRecordsMulti(filters FilterFunctions, reversed bool) (func(), chan FilterResult, chan struct{}) {
+	results := make(chan FilterResult, 64)
+	stop := make(chan struct{})
+
+	return func() {
+		defer close(results)
+
+		// Only restricts the directories to search, filters are applied after namespacing
+		timeFilters := FilterFunctions{Start: filters.Start, End: filters.End}
+		records := []Record{}
+		for _, ws := range t.readWorkspaces {
+			view, err := t.workspaceView(ws)
+			if err != nil {
+				results <- FilterResult{Record{}, err}
+				return
+			}
+			wsRecords, err := view.LoadAllRecordsFiltered(timeFilters)
+			if err != nil {
+				results <- FilterResult{Record{}, err}
+				return
+			}
+			for _, rec := range wsRecords {
+				rec.Project = NamespaceProject(ws, rec.Project)
+				if Filter(&rec, filters) {
+					records = append(records, rec)
+				}
+			}
+		}
+		sort.SliceStable(records, func(i, j int) bool {
+			if reversed {
+				return records[j].Start.Before(records[i].Start)
+			}
+			return records[i].Start.Before(records[j].Start)
+		})
+
+		for _, rec := range records {
+			select {
+			case <-stop:
+				return
+			case results <- FilterResult{rec, nil}:
+			}
+		}
+	}, results, stop
+}
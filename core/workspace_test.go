@@ -2,9 +2,13 @@ package core
 
 import (
 	"os"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/mlange-42/track/util"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/maps"
 )
 
 func TestWorkspace(t *testing.T) {
@@ -33,3 +37,225 @@ func TestWorkspace(t *testing.T) {
 	assert.Nil(t, err, "Error listing workspace")
 	assert.Equal(t, []string{"default", "test-ws"}, allWs, "Workspace should be test-ws")
 }
+
+func TestWorkspaceConfig(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.Remove(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	err = track.CreateWorkspace("test-ws")
+	assert.Nil(t, err, "Error creating workspace")
+
+	breakDur := time.Hour
+	weekStart := "sunday"
+	wsConf := WorkspaceConfig{
+		MaxBreakDuration: &breakDur,
+		WeekStart:        &weekStart,
+	}
+	err = wsConf.Save(track.WorkspaceConfigPath("test-ws"))
+	assert.Nil(t, err, "Error saving workspace config")
+
+	err = track.SwitchWorkspace("test-ws")
+	assert.Nil(t, err, "Error switching workspace")
+
+	assert.Equal(t, time.Hour, track.Config.MaxBreakDuration, "Wrong effective break duration")
+	assert.Equal(t, "sunday", track.Config.WeekStart, "Wrong effective week start")
+	assert.Equal(t, time.Sunday, track.Config.Calendar().FirstWeekday, "Wrong calendar week start")
+	assert.Equal(t, 2*time.Hour, track.GlobalConfig.MaxBreakDuration, "Global config should not be changed")
+
+	entries, err := track.EffectiveConfig()
+	assert.Nil(t, err, "Error getting effective config")
+	sources := map[string]string{}
+	for _, e := range entries {
+		sources[e.Key] = e.Source
+	}
+	assert.Equal(t, "workspace 'test-ws'", sources["maxBreakDuration"], "Wrong config source")
+	assert.Equal(t, "global", sources["textEditor"], "Wrong config source")
+
+	err = track.SwitchWorkspace("default")
+	assert.Nil(t, err, "Error switching workspace")
+	assert.Equal(t, 2*time.Hour, track.Config.MaxBreakDuration, "Workspace override should not apply")
+	assert.Equal(t, "monday", track.Config.WeekStart, "Workspace override should not apply")
+	assert.Equal(t, time.Monday, track.Config.Calendar().FirstWeekday, "Calendar should not leak between workspaces")
+}
+
+func TestReadWorkspaces(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+	assert.Nil(t, track.CreateWorkspace("other"))
+
+	assert.Nil(t, track.SaveProject(NewProject("parent", "", "p", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveProject(NewProject("child", "parent", "c", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveRecord(&Record{
+		Project: "child",
+		Start:   util.DateTime(2023, 3, 13, 9, 0, 0),
+		End:     util.DateTime(2023, 3, 13, 10, 0, 0),
+	}, false))
+
+	other, err := track.workspaceView("other")
+	assert.Nil(t, err)
+	assert.Nil(t, other.SaveProject(NewProject("parent", "", "p", []string{}, 0, 0), false))
+	assert.Nil(t, other.SaveRecord(&Record{
+		Project: "parent",
+		Start:   util.DateTime(2023, 3, 13, 8, 0, 0),
+		End:     util.DateTime(2023, 3, 13, 8, 30, 0),
+	}, false))
+
+	assert.NotNil(t, track.ReadWorkspaces([]string{"default", "missing"}))
+	assert.Nil(t, track.ReadWorkspaces([]string{"default", "other"}))
+	assert.True(t, track.IsMultiWorkspace())
+
+	projects, err := track.LoadAllProjects()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default/child", "default/parent", "other/parent"}, sortedKeys(projects))
+	assert.Equal(t, "default/parent", projects["default/child"].Parent)
+
+	tree, err := track.ToProjectTree(projects)
+	assert.Nil(t, err)
+	assert.Equal(t, AllWorkspacesLabel, tree.Root.Value.Name)
+	assert.Equal(t, "<default>", tree.Nodes["default/parent"].Parent.Value.Name)
+	assert.Equal(t, "<other>", tree.Nodes["other/parent"].Parent.Value.Name)
+	assert.Equal(t, "default/parent", tree.Nodes["default/child"].Parent.Value.Name)
+
+	records, err := track.LoadAllRecordsFiltered(NewFilter(
+		[]FilterFunction{FilterByProjects([]string{"default/child", "other/parent"})}, util.NoTime, util.NoTime,
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "other/parent", records[0].Project, "Records should be in chronological order")
+	assert.Equal(t, "default/child", records[1].Project)
+
+	records, err = track.LoadDateRecordsExact(util.Date(2023, 3, 13))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+
+	reporter, err := NewReporter(&track, []string{}, NewFilter([]FilterFunction{}, util.NoTime, util.NoTime), false, util.NoTime, util.NoTime)
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, reporter.TotalTime[AllWorkspacesLabel])
+	assert.Equal(t, 60*time.Minute, reporter.TotalTime["<default>"])
+	assert.Equal(t, 30*time.Minute, reporter.TotalTime["<other>"])
+
+	assert.Nil(t, track.ReadWorkspaces(nil))
+	projects, err = track.LoadAllProjects()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"child", "parent"}, sortedKeys(projects))
+}
+
+func TestReadWorkspacesConfig(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+	assert.Nil(t, track.CreateWorkspace("other"))
+
+	wsConf := WorkspaceConfig{TagAliases: map[string]string{"mtg": "meeting"}}
+	assert.Nil(t, wsConf.Save(track.WorkspaceConfigPath("other")))
+
+	other, err := track.workspaceView("other")
+	assert.Nil(t, err)
+	assert.Equal(t, "other", other.Workspace())
+	assert.Equal(t, map[string]string{"mtg": "meeting"}, other.Config.TagAliases, "View should use the workspace's config")
+
+	for _, tr := range []*Track{&track, other} {
+		assert.Nil(t, tr.SaveProject(NewProject("p", "", "p", []string{}, 0, 0), false))
+		assert.Nil(t, tr.SaveRecord(&Record{
+			Project: "p",
+			Start:   util.DateTime(2023, 3, 13, 9, 0, 0),
+			End:     util.DateTime(2023, 3, 13, 10, 0, 0),
+			Note:    "+mtg",
+			Tags:    map[string]string{"mtg": ""},
+		}, false))
+	}
+
+	assert.Nil(t, track.ReadWorkspaces([]string{"default", "other"}))
+	records, err := track.LoadAllRecords()
+	assert.Nil(t, err)
+	tags := map[string]map[string]string{}
+	for _, rec := range records {
+		tags[rec.Project] = rec.Tags
+	}
+	assert.Equal(t, map[string]string{"mtg": ""}, tags["default/p"], "Aliases of another workspace should not apply")
+	assert.Equal(t, map[string]string{"meeting": ""}, tags["other/p"], "Aliases of the record's workspace should apply")
+}
+
+func sortedKeys(m map[string]Project) []string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
+
+func TestWorkspaceManagement(t *testing.T) {
+	dir, err := os.MkdirTemp("", "track-test")
+	assert.Nil(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	track, err := NewTrack(&dir)
+	assert.Nil(t, err, "Error creating Track instance")
+
+	assert.NotNil(t, track.CreateWorkspace("a/b"), "Should fail for invalid name")
+
+	assert.Nil(t, track.SaveProject(NewProject("parent", "", "p", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveProject(NewProject("child", "parent", "c", []string{}, 0, 0), false))
+	assert.Nil(t, track.SaveRecord(&Record{
+		Project: "child",
+		Start:   util.DateTime(2023, 3, 13, 9, 0, 0),
+		End:     util.DateTime(2023, 3, 13, 10, 0, 0),
+	}, false))
+
+	projects, records, err := track.CloneWorkspace("template", "default", true)
+	assert.Nil(t, err, "Error cloning workspace")
+	assert.Equal(t, 2, projects)
+	assert.Equal(t, 0, records)
+	_, _, err = track.CloneWorkspace("template", "default", true)
+	assert.NotNil(t, err, "Should fail for existing workspace")
+
+	projects, records, err = track.CloneWorkspace("copy", "default", false)
+	assert.Nil(t, err, "Error cloning workspace")
+	assert.Equal(t, 2, projects)
+	assert.Equal(t, 1, records)
+
+	template, err := track.workspaceView("template")
+	assert.Nil(t, err)
+	copied, err := template.LoadAllProjects()
+	assert.Nil(t, err)
+	assert.Equal(t, "parent", copied["child"].Parent)
+
+	assert.Nil(t, track.RenameWorkspace("default", "main"), "Error renaming workspace")
+	assert.Equal(t, "main", track.Workspace(), "Current workspace should be renamed")
+	assert.False(t, track.WorkspaceExists("default"))
+	assert.NotNil(t, track.RenameWorkspace("main", "copy"), "Should fail for existing workspace")
+
+	reloaded, err := NewTrack(&dir)
+	assert.Nil(t, err)
+	assert.Equal(t, "main", reloaded.Workspace(), "Renamed workspace should be saved")
+
+	assert.NotNil(t, track.ArchiveWorkspace("main", true), "Should fail for current workspace")
+	assert.Nil(t, track.ArchiveWorkspace("copy", true))
+	active, err := track.ActiveWorkspaces()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"main", "template"}, active)
+	assert.NotNil(t, track.ReadWorkspaces([]string{"copy"}), "Should fail for archived workspace")
+	assert.Nil(t, track.ArchiveWorkspace("copy", false))
+	assert.Nil(t, track.ReadWorkspaces([]string{"copy"}))
+	assert.Nil(t, track.ReadWorkspaces(nil))
+
+	_, _, err = track.DeleteWorkspace("main", false)
+	assert.NotNil(t, err, "Should fail for current workspace")
+	projects, records, err = track.DeleteWorkspace("copy", true)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, projects)
+	assert.Equal(t, 1, records)
+	assert.True(t, track.WorkspaceExists("copy"), "Dry run should not delete")
+	_, _, err = track.DeleteWorkspace("copy", false)
+	assert.Nil(t, err)
+	assert.False(t, track.WorkspaceExists("copy"))
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"os"
 
-	"github.com/gookit/color"
 	"github.com/mlange-42/track/cli"
 	"github.com/mlange-42/track/core"
 	"github.com/mlange-42/track/out"
@@ -12,9 +11,8 @@ import (
 const version = "0.3.7"
 
 func main() {
-	if !color.Support256Color() || !isTerminal() {
-		color.Disable()
-	}
+	// Colors for errors before the command line is parsed. Flag --color-mode is applied by the root command
+	_ = out.SetColorMode(out.ColorAuto)
 
 	track, err := core.NewTrack(nil)
 	if err != nil {
@@ -27,8 +25,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func isTerminal() bool {
-	o, _ := os.Stdout.Stat()
-	return (o.Mode() & os.ModeCharDevice) == os.ModeCharDevice
-}
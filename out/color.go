@@ -0,0 +1,120 @@
+package out
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/mlange-42/track/util"
+)
+
+// Color modes
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ColorModes are the available color modes
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// detectedLevel is the color level supported by the terminal
+var detectedLevel = color.TermColorLevel()
+
+// SetColorMode enables or disables colored output.
+//
+// In mode auto, output is colored if standard output is a terminal and NO_COLOR is not set.
+// In mode always, output is colored, with 256 colors if the terminal's color support is not detected.
+func SetColorMode(mode string) error {
+	switch mode {
+	case ColorAuto:
+		color.ForceSetColorLevel(detectedLevel)
+		color.Enable = os.Getenv("NO_COLOR") == "" && IsTerminal()
+	case ColorAlways:
+		level := detectedLevel
+		if level == color.LevelNo {
+			level = color.Level256
+		}
+		color.ForceSetColorLevel(level)
+		color.Enable = true
+	case ColorNever:
+		color.Enable = false
+	default:
+		return fmt.Errorf("invalid color mode '%s', expected one of: %s", mode, strings.Join(ColorModes, ", "))
+	}
+	return nil
+}
+
+// Style renders text in a foreground and a background color.
+// Colors fall back to the closest colors supported by the terminal.
+// The zero value renders text without colors.
+type Style struct {
+	fg      util.Color
+	bg      util.Color
+	colored bool
+}
+
+// NewStyle creates a new style
+func NewStyle(fg, bg util.Color) Style {
+	return Style{fg: fg, bg: bg, colored: true}
+}
+
+// Sprint returns the rendered message
+func (s Style) Sprint(a ...interface{}) string {
+	return color.RenderCode(s.code(), a...)
+}
+
+// Sprintf returns the formatted and rendered message
+func (s Style) Sprintf(format string, a ...interface{}) string {
+	return color.RenderString(s.code(), fmt.Sprintf(format, a...))
+}
+
+// code returns the color code of the style, for the color level of the terminal
+func (s Style) code() string {
+	if !s.colored {
+		return ""
+	}
+	return colorCode(s.fg, false) + ";" + colorCode(s.bg, true)
+}
+
+// colorCode returns the color code of a color, for the color level of the terminal
+func colorCode(c util.Color, bg bool) string {
+	base := 38
+	if bg {
+		base = 48
+	}
+	switch level := color.TermColorLevel(); {
+	case level >= color.LevelRgb && c.True:
+		return fmt.Sprintf("%d;2;%d;%d;%d", base, c.R, c.G, c.B)
+	case level >= color.Level256:
+		index := c.Index
+		if c.True {
+			index = color.RgbTo256(c.R, c.G, c.B)
+		}
+		return fmt.Sprintf("%d;5;%d", base, index)
+	default:
+		index := basicIndex(c)
+		if index < 8 {
+			return fmt.Sprint(base - 8 + int(index))
+		}
+		return fmt.Sprint(base + 52 + int(index) - 8)
+	}
+}
+
+// basicIndex returns the index of the closest of the 16 basic colors
+func basicIndex(c util.Color) uint8 {
+	if !c.True && c.Index < 16 {
+		return c.Index
+	}
+	r, g, b := c.RGB()
+	best, bestDist := uint8(0), -1
+	for i := uint8(0); i < 16; i++ {
+		rgb := color.C256ToRgb(i)
+		dr, dg, db := int(r)-int(rgb[0]), int(g)-int(rgb[1]), int(b)-int(rgb[2])
+		if dist := dr*dr + dg*dg + db*db; bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
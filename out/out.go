@@ -5,14 +5,7 @@ import (
 	"io"
 	"os"
 
-	"github.com/gookit/color"
-)
-
-var (
-	successColor = color.BgGreen
-	warningColor = color.BgYellow
-	errorColor   = color.BgRed
-	promptColor  = color.BgBlue
+	"golang.org/x/term"
 )
 
 // StdOut is the writer for standard output. Defaults to os.Stdout
@@ -31,25 +24,25 @@ func Print(format string, a ...interface{}) {
 
 // Err prints an error
 func Err(format string, a ...interface{}) {
-	fmt.Fprintf(StdOut, "%s ", errorColor.Sprint(" ERROR   "))
+	fmt.Fprintf(StdOut, "%s ", theme.Error.Sprint(" ERROR   "))
 	printErr(format, a...)
 }
 
 // Warn prints a warning message
 func Warn(format string, a ...interface{}) {
-	fmt.Fprintf(StdOut, "%s ", warningColor.Sprint(" WARNING "))
+	fmt.Fprintf(StdOut, "%s ", theme.Warning.Sprint(" WARNING "))
 	printErr(format, a...)
 }
 
 // Success prints a success message
 func Success(format string, a ...interface{}) {
-	fmt.Fprintf(StdOut, "%s ", successColor.Sprint(" SUCCESS "))
+	fmt.Fprintf(StdOut, "%s ", theme.Success.Sprint(" SUCCESS "))
 	printErr(format, a...)
 }
 
 // Scan prints a prompt message and scans for user input
 func Scan(format string, a ...interface{}) (string, error) {
-	fmt.Fprintf(StdOut, "%s ", promptColor.Sprint(" PROMPT  "))
+	fmt.Fprintf(StdOut, "%s ", theme.Prompt.Sprint(" PROMPT  "))
 	printOut(format, a...)
 
 	var answer string
@@ -57,6 +50,12 @@ func Scan(format string, a ...interface{}) (string, error) {
 	return answer, err
 }
 
+// IsTerminal reports whether standard output is a terminal
+func IsTerminal() bool {
+	f, ok := StdOut.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
 func printOut(format string, a ...interface{}) {
 	fmt.Fprintf(StdOut, format, a...)
 }
@@ -0,0 +1,84 @@
+package out
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gookit/color"
+	"golang.org/x/exp/maps"
+)
+
+// DefaultTheme is the name of the default theme
+const DefaultTheme = "default"
+
+// Theme holds the colors of messages and highlighted UI elements
+type Theme struct {
+	Success   color.Style
+	Warning   color.Style
+	Error     color.Style
+	Prompt    color.Style
+	Highlight color.Style
+}
+
+// Themes are the available themes, by name
+var Themes = map[string]Theme{
+	DefaultTheme: {
+		Success:   color.New(color.BgGreen),
+		Warning:   color.New(color.BgYellow),
+		Error:     color.New(color.BgRed),
+		Prompt:    color.New(color.BgBlue),
+		Highlight: color.New(color.BgBlue),
+	},
+	"bright": {
+		Success:   color.New(color.FgBlack, color.BgHiGreen),
+		Warning:   color.New(color.FgBlack, color.BgHiYellow),
+		Error:     color.New(color.FgBlack, color.BgHiRed),
+		Prompt:    color.New(color.FgBlack, color.BgHiCyan),
+		Highlight: color.New(color.FgBlack, color.BgHiCyan),
+	},
+	"mono": {
+		Success:   color.New(color.OpBold),
+		Warning:   color.New(color.OpReverse),
+		Error:     color.New(color.OpReverse, color.OpBold),
+		Prompt:    color.New(color.OpUnderscore),
+		Highlight: color.New(color.OpReverse),
+	},
+}
+
+var theme = Themes[DefaultTheme]
+
+// ThemeNames returns the sorted names of all available themes
+func ThemeNames() []string {
+	names := maps.Keys(Themes)
+	sort.Strings(names)
+	return names
+}
+
+// CheckTheme checks if a theme exists. An empty name refers to the default theme.
+func CheckTheme(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := Themes[name]; !ok {
+		return fmt.Errorf("unknown theme '%s', expected one of: %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	return nil
+}
+
+// SetTheme sets the theme by name. An empty name selects the default theme.
+func SetTheme(name string) error {
+	if err := CheckTheme(name); err != nil {
+		return err
+	}
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme = Themes[name]
+	return nil
+}
+
+// Highlight renders a text highlighted in the color of the current theme, like the workspace in project trees
+func Highlight(text string) string {
+	return theme.Highlight.Sprint(text)
+}
@@ -0,0 +1,222 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	fontSize = 10.0
+	// textWidth is the approximate width of a character, for layout
+	textWidth = float64(glyphAdvance)
+)
+
+// Anchor is the horizontal alignment of a text
+type Anchor int
+
+// Text anchors
+const (
+	AnchorStart Anchor = iota
+	AnchorMiddle
+	AnchorEnd
+)
+
+var svgAnchors = [...]string{"start", "middle", "end"}
+
+// Colors used by charts
+var (
+	White     = color.RGBA{255, 255, 255, 255}
+	Black     = color.RGBA{0, 0, 0, 255}
+	TextColor = color.RGBA{0x44, 0x44, 0x44, 255}
+	GridColor = color.RGBA{0xdd, 0xdd, 0xdd, 255}
+	AxisColor = color.RGBA{0x88, 0x88, 0x88, 255}
+)
+
+// Canvas is a simple vector image of rectangles, lines and texts, that can be rendered as SVG or PNG
+type Canvas struct {
+	Width  int
+	Height int
+	shapes []shape
+}
+
+// shape is an element of a canvas
+type shape interface {
+	svg(sb *strings.Builder)
+	draw(img *image.RGBA, scale int)
+}
+
+// NewCanvas creates a new canvas with a white background
+func NewCanvas(width, height int) *Canvas {
+	return &Canvas{Width: width, Height: height}
+}
+
+// Rect adds a filled rectangle, with an optional tooltip title for SVG
+func (c *Canvas) Rect(x, y, w, h float64, fill color.RGBA, title string) {
+	c.shapes = append(c.shapes, &rect{x, y, w, h, fill, false, title})
+}
+
+// HatchedRect adds a rectangle with diagonal hatching in the given color, like for pauses
+func (c *Canvas) HatchedRect(x, y, w, h float64, fill color.RGBA, title string) {
+	c.shapes = append(c.shapes, &rect{x, y, w, h, fill, true, title})
+}
+
+// Line adds a horizontal or vertical line
+func (c *Canvas) Line(x1, y1, x2, y2 float64, col color.RGBA) {
+	c.shapes = append(c.shapes, &line{x1, y1, x2, y2, col})
+}
+
+// Text adds a text, with y at the baseline
+func (c *Canvas) Text(x, y float64, text string, col color.RGBA, anchor Anchor) {
+	c.shapes = append(c.shapes, &label{x, y, text, col, anchor})
+}
+
+// TextWidth returns the approximate width of a text
+func TextWidth(text string) float64 {
+	return float64(utf8.RuneCountInString(text)) * textWidth
+}
+
+// hatchColors returns the colors of all hatched rectangles
+func (c *Canvas) hatchColors() []color.RGBA {
+	colors := []color.RGBA{}
+	found := map[color.RGBA]bool{}
+	for _, s := range c.shapes {
+		if r, ok := s.(*rect); ok && r.Hatched && !found[r.Fill] {
+			found[r.Fill] = true
+			colors = append(colors, r.Fill)
+		}
+	}
+	return colors
+}
+
+type rect struct {
+	X, Y, W, H float64
+	Fill       color.RGBA
+	Hatched    bool
+	Title      string
+}
+
+func (r *rect) svg(sb *strings.Builder) {
+	fill := hex(r.Fill)
+	if r.Hatched {
+		fill = fmt.Sprintf("url(#%s)", hatchID(r.Fill))
+	}
+	fmt.Fprintf(sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"`, r.X, r.Y, r.W, r.H, fill)
+	if r.Hatched {
+		fmt.Fprintf(sb, ` stroke="%s" stroke-width="0.5"`, hex(r.Fill))
+	}
+	if r.Title == "" {
+		sb.WriteString("/>\n")
+		return
+	}
+	fmt.Fprintf(sb, "><title>%s</title></rect>\n", escape(r.Title))
+}
+
+func (r *rect) draw(img *image.RGBA, scale int) {
+	x0, y0 := px(r.X, scale), px(r.Y, scale)
+	x1, y1 := px(r.X+r.W, scale), px(r.Y+r.H, scale)
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if !r.Hatched || ((x+y)/scale)%4 == 0 {
+				img.SetRGBA(x, y, r.Fill)
+			} else {
+				img.SetRGBA(x, y, White)
+			}
+		}
+	}
+}
+
+type line struct {
+	X1, Y1, X2, Y2 float64
+	Color          color.RGBA
+}
+
+func (l *line) svg(sb *strings.Builder) {
+	fmt.Fprintf(sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", l.X1, l.Y1, l.X2, l.Y2, hex(l.Color))
+}
+
+func (l *line) draw(img *image.RGBA, scale int) {
+	x0, y0 := px(math.Min(l.X1, l.X2), scale), px(math.Min(l.Y1, l.Y2), scale)
+	x1, y1 := px(math.Max(l.X1, l.X2), scale), px(math.Max(l.Y1, l.Y2), scale)
+	if x1 == x0 {
+		x1 = x0 + scale
+	}
+	if y1 == y0 {
+		y1 = y0 + scale
+	}
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			img.SetRGBA(x, y, l.Color)
+		}
+	}
+}
+
+type label struct {
+	X, Y   float64
+	Text   string
+	Color  color.RGBA
+	Anchor Anchor
+}
+
+func (t *label) svg(sb *strings.Builder) {
+	anchor := ""
+	if t.Anchor != AnchorStart {
+		anchor = fmt.Sprintf(` text-anchor="%s"`, svgAnchors[t.Anchor])
+	}
+	fmt.Fprintf(sb, `<text x="%.1f" y="%.1f" fill="%s"%s>%s</text>`+"\n", t.X, t.Y, hex(t.Color), anchor, escape(t.Text))
+}
+
+func (t *label) draw(img *image.RGBA, scale int) {
+	x := t.X
+	switch t.Anchor {
+	case AnchorMiddle:
+		x -= TextWidth(t.Text) / 2
+	case AnchorEnd:
+		x -= TextWidth(t.Text)
+	}
+	x0 := px(x, scale)
+	y0 := px(t.Y, scale) - glyphHeight*scale
+	for i, r := range []rune(t.Text) {
+		glyph, ok := glyphs[r]
+		if !ok {
+			glyph = glyphs['?']
+		}
+		gx := x0 + i*glyphAdvance*scale
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						img.SetRGBA(gx+col*scale+dx, y0+row*scale+dy, t.Color)
+					}
+				}
+			}
+		}
+	}
+}
+
+// hatchID returns the SVG pattern ID for hatching in the given color
+func hatchID(c color.RGBA) string {
+	return fmt.Sprintf("hatch-%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// px converts a canvas coordinate to a pixel coordinate
+func px(v float64, scale int) int {
+	return int(math.Round(v * float64(scale)))
+}
+
+// hex formats a color as #rrggbb
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+var svgEscape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+
+func escape(s string) string {
+	return svgEscape.Replace(s)
+}
@@ -0,0 +1,23 @@
+package chart
+
+import (
+	"image/color"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+)
+
+// ProjectColor returns the background color of a project
+func ProjectColor(p *core.Project) color.RGBA {
+	return rgba(p.Color)
+}
+
+// ProjectTextColor returns the foreground color of a project
+func ProjectTextColor(p *core.Project) color.RGBA {
+	return rgba(p.FgColor)
+}
+
+func rgba(c util.Color) color.RGBA {
+	r, g, b := c.RGB()
+	return color.RGBA{r, g, b, 255}
+}
@@ -0,0 +1,30 @@
+package chart
+
+import (
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// PngRenderer renders a canvas as a PNG image
+type PngRenderer struct {
+	Canvas *Canvas
+	// Pixels per canvas unit
+	Scale int
+}
+
+// Render renders the canvas
+func (r PngRenderer) Render(w io.Writer) error {
+	scale := r.Scale
+	if scale < 1 {
+		scale = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, r.Canvas.Width*scale, r.Canvas.Height*scale))
+	draw.Draw(img, img.Bounds(), &image.Uniform{White}, image.Point{}, draw.Src)
+
+	for _, s := range r.Canvas.shapes {
+		s.draw(img, scale)
+	}
+	return png.Encode(w, img)
+}
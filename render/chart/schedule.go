@@ -0,0 +1,203 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	hourAxisHeight = 14.0
+	rowGap         = 4.0
+)
+
+// gantt is the layout of a Gantt-style chart, with rows over the hours of a day
+type gantt struct {
+	Left      float64
+	Top       float64
+	HourWidth float64
+	RowHeight float64
+}
+
+// x returns the horizontal position of a time, relative to the start of the row's day
+func (g *gantt) x(t, dayStart time.Time) float64 {
+	return g.Left + t.Sub(dayStart).Hours()*g.HourWidth
+}
+
+// y returns the vertical position of a row
+func (g *gantt) y(row int) float64 {
+	return g.Top + float64(row)*(g.RowHeight+rowGap)
+}
+
+// right returns the horizontal position of the end of the day
+func (g *gantt) right() float64 {
+	return g.Left + 24*g.HourWidth
+}
+
+// drawAxis draws hour labels and vertical grid lines for the given number of rows
+func (g *gantt) drawAxis(c *Canvas, rows int, labelEvery int) {
+	bottom := g.y(rows) - rowGap
+	for h := 0; h <= 24; h++ {
+		x := g.Left + float64(h)*g.HourWidth
+		c.Line(x, g.Top, x, bottom, GridColor)
+		if h%labelEvery == 0 {
+			c.Text(x, g.Top-4, fmt.Sprintf("%02d:00", h), TextColor, AnchorMiddle)
+		}
+	}
+}
+
+// drawRecord draws the part of a record that falls into the day of a row, with hatched pauses
+func (g *gantt) drawRecord(c *Canvas, rec *core.Record, project *core.Project, dayStart time.Time, row int, label bool) {
+	dayEnd := util.AddDays(dayStart, 1)
+	start, end, ok := clip(rec.Start, rec.End, dayStart, dayEnd)
+	if !ok {
+		return
+	}
+	col := AxisColor
+	textCol := White
+	if project != nil {
+		col = ProjectColor(project)
+		textCol = ProjectTextColor(project)
+	}
+
+	x0, x1 := g.x(start, dayStart), g.x(end, dayStart)
+	y := g.y(row)
+	title := fmt.Sprintf("%s %s - %s", rec.Project, rec.Start.Format(util.TimeFormat), end.Format(util.TimeFormat))
+	if rec.Note != "" {
+		title += ": " + strings.ReplaceAll(rec.Note, "\n", " ")
+	}
+	c.Rect(x0, y, x1-x0, g.RowHeight, col, title)
+
+	for _, p := range rec.Pause {
+		pStart, pEnd, ok := clip(p.Start, p.End, start, end)
+		if !ok {
+			continue
+		}
+		px0, px1 := g.x(pStart, dayStart), g.x(pEnd, dayStart)
+		c.HatchedRect(px0, y, px1-px0, g.RowHeight, col, "pause")
+	}
+
+	if label && x1-x0 > TextWidth(rec.Project)+6 {
+		c.Text(x0+3, y+g.RowHeight/2+3.5, rec.Project, textCol, AnchorStart)
+	}
+}
+
+// clip clips a time range to the range between min and max. Open ranges end now.
+func clip(start, end, min, max time.Time) (time.Time, time.Time, bool) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.Before(min) {
+		start = min
+	}
+	if end.After(max) {
+		end = max
+	}
+	return start, end, start.Before(end)
+}
+
+// Schedule creates a Gantt-style chart of records, with one row per day, starting at the given date.
+// Pauses are hatched.
+func Schedule(title string, r *core.Reporter, startDate time.Time, days int) *Canvas {
+	labelFormat := "Mon " + util.DateFormat
+	g := gantt{Left: margin + TextWidth(labelFormat) + 10, Top: titleHeight + hourAxisHeight, HourWidth: 30, RowHeight: 24}
+	labelEvery := 2
+	if days == 1 {
+		g.HourWidth, g.RowHeight, labelEvery = 40, 40, 1
+	}
+
+	used := map[string]bool{}
+	for _, rec := range r.Records {
+		used[rec.Project] = true
+	}
+	names := maps.Keys(used)
+	sort.Strings(names)
+
+	width := g.right() + 50
+	legendRows, legendHeight := legendLayout(names, width)
+	height := g.y(days) + legendHeight + margin
+
+	c := NewCanvas(int(width), int(math.Ceil(height)))
+	c.Text(margin, titleHeight-6, title, TextColor, AnchorStart)
+	g.drawAxis(c, days, labelEvery)
+
+	for d := 0; d < days; d++ {
+		dayStart := util.AddDays(startDate, d)
+		y := g.y(d)
+		c.Text(margin, y+g.RowHeight/2+3.5, dayStart.Format(labelFormat), TextColor, AnchorStart)
+
+		var total time.Duration
+		for i := range r.Records {
+			rec := &r.Records[i]
+			var project *core.Project
+			if p, ok := r.AllProjects[rec.Project]; ok {
+				project = &p
+			}
+			g.drawRecord(c, rec, project, dayStart, d, true)
+			total += rec.Duration(dayStart, util.AddDays(dayStart, 1))
+		}
+		c.Text(g.right()+6, y+g.RowHeight/2+3.5, util.FormatDuration(total), TextColor, AnchorStart)
+	}
+
+	drawLegend(c, legendRows, r.AllProjects, g.y(days)+4)
+	return c
+}
+
+// DayChart creates a Gantt-style chart of the records of a day, with one row per project in tree order.
+// Pauses are hatched.
+func DayChart(title string, r *core.Reporter, tree *core.ProjectTree, startDate time.Time) *Canvas {
+	type row struct {
+		Node  *core.ProjectNode
+		Depth int
+	}
+	rows := []row{}
+	var walk func(node *core.ProjectNode, depth int)
+	walk = func(node *core.ProjectNode, depth int) {
+		children := maps.Keys(node.Children)
+		sort.Strings(children)
+		for _, child := range children {
+			rows = append(rows, row{node.Children[child], depth})
+			walk(node.Children[child], depth+1)
+		}
+	}
+	walk(tree.Root, 0)
+
+	labelWidth := 0.0
+	for _, rw := range rows {
+		labelWidth = math.Max(labelWidth, TextWidth(strings.Repeat("  ", rw.Depth)+rw.Node.Value.Name))
+	}
+
+	g := gantt{Left: margin + labelWidth + 10, Top: titleHeight + hourAxisHeight, HourWidth: 30, RowHeight: 14}
+	width := g.right() + 50
+	height := g.y(len(rows)) + margin
+
+	c := NewCanvas(int(width), int(math.Ceil(height)))
+	c.Text(margin, titleHeight-6, title, TextColor, AnchorStart)
+	g.drawAxis(c, len(rows), 2)
+
+	indices := map[string]int{}
+	for i, rw := range rows {
+		name := rw.Node.Value.Name
+		indices[name] = i
+		y := g.y(i)
+		c.Text(margin+TextWidth(strings.Repeat("  ", rw.Depth)), y+g.RowHeight/2+3.5, name, TextColor, AnchorStart)
+		c.Text(g.right()+6, y+g.RowHeight/2+3.5, util.FormatDuration(r.TotalTime[name]), TextColor, AnchorStart)
+	}
+
+	for i := range r.Records {
+		rec := &r.Records[i]
+		idx, ok := indices[rec.Project]
+		if !ok {
+			continue
+		}
+		project := rows[idx].Node.Value
+		g.drawRecord(c, rec, &project, startDate, idx, false)
+	}
+	return c
+}
@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupReporter creates a reporter like cli's renderSchedule, with the filter starting a day earlier
+func setupReporter(t *testing.T, records []core.Record, start, end time.Time) (*core.Reporter, func()) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	track, err := core.NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+
+	for i, name := range []string{"p1", "p2"} {
+		if err := track.SaveProject(core.NewProject(name, "", "p", []string{}, 15, uint8(i+1)), false); err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+	for _, rec := range records {
+		if err := track.SaveRecord(&rec, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err := core.NewReporter(
+		&track, []string{}, core.NewFilter([]core.FilterFunction{}, util.AddDays(start, -1), end),
+		false, start, end,
+	)
+	if err != nil {
+		t.Fatalf("error creating reporter: %s", err)
+	}
+	return reporter, func() { os.RemoveAll(dir) }
+}
+
+// shapesOf returns the titled rectangles and the texts of a canvas
+func shapesOf(c *Canvas) ([]rect, []label) {
+	rects := []rect{}
+	labels := []label{}
+	for _, s := range c.shapes {
+		switch s := s.(type) {
+		case *rect:
+			if s.Title != "" {
+				rects = append(rects, *s)
+			}
+		case *label:
+			labels = append(labels, *s)
+		}
+	}
+	return rects, labels
+}
+
+func TestSchedule(t *testing.T) {
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 5, 22, 0, 0), End: util.DateTime(2023, 3, 6, 2, 0, 0)},
+		{Project: "p2", Start: util.DateTime(2023, 3, 6, 9, 0, 0), End: util.DateTime(2023, 3, 6, 11, 0, 0)},
+		{
+			Project: "p1", Start: util.DateTime(2023, 3, 6, 10, 0, 0), End: util.DateTime(2023, 3, 6, 12, 0, 0),
+			Pause: []core.Pause{{Start: util.DateTime(2023, 3, 6, 11, 0, 0), End: util.DateTime(2023, 3, 6, 11, 30, 0)}},
+		},
+	}
+
+	cal := util.Calendar{FirstWeekday: time.Sunday, Period: util.DefaultCalendar().Period}
+	start := cal.WeekStart(util.Date(2023, 3, 8))
+	assert.Equal(t, util.Date(2023, 3, 5), start, "Week should start on Sunday")
+
+	reporter, cleanup := setupReporter(t, records, start, util.AddDays(start, 7))
+	defer cleanup()
+
+	c := Schedule("Week", reporter, start, 7)
+
+	g := gantt{Left: margin + TextWidth("Mon "+util.DateFormat) + 10, Top: titleHeight + hourAxisHeight, HourWidth: 30, RowHeight: 24}
+	rects, labels := shapesOf(c)
+
+	proj1, proj2 := reporter.AllProjects["p1"], reporter.AllProjects["p2"]
+	p1, p2 := ProjectColor(&proj1), ProjectColor(&proj2)
+	assert.Equal(t, []rect{
+		{X: g.Left + 22*30, Y: g.y(0), W: 60, H: 24, Fill: p1, Title: "p1 22:00 - 00:00"},
+		{X: g.Left, Y: g.y(1), W: 60, H: 24, Fill: p1, Title: "p1 22:00 - 02:00"},
+		{X: g.Left + 9*30, Y: g.y(1), W: 60, H: 24, Fill: p2, Title: "p2 09:00 - 11:00"},
+		{X: g.Left + 10*30, Y: g.y(1), W: 60, H: 24, Fill: p1, Title: "p1 10:00 - 12:00"},
+		{X: g.Left + 11*30, Y: g.y(1), W: 15, H: 24, Fill: p1, Hatched: true, Title: "pause"},
+	}, rects, "Records spanning midnight should be split, overlapping records should share a row")
+
+	rowLabels := []string{}
+	totals := []string{}
+	for _, l := range labels {
+		if l.X == margin && l.Y > g.Top {
+			rowLabels = append(rowLabels, l.Text)
+		}
+		if l.X == g.right()+6 {
+			totals = append(totals, l.Text)
+		}
+	}
+	assert.Equal(t, []string{
+		"Sun 2023-03-05", "Mon 2023-03-06", "Tue 2023-03-07", "Wed 2023-03-08",
+		"Thu 2023-03-09", "Fri 2023-03-10", "Sat 2023-03-11",
+	}, rowLabels, "Rows should start at the first weekday")
+	assert.Equal(t, []string{"02:00", "05:30", "00:00", "00:00", "00:00", "00:00", "00:00"}, totals, "Wrong day totals")
+}
+
+func TestScheduleDay(t *testing.T) {
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 5, 22, 0, 0), End: util.DateTime(2023, 3, 6, 2, 0, 0)},
+	}
+	start := util.Date(2023, 3, 6)
+	reporter, cleanup := setupReporter(t, records, start, util.AddDays(start, 1))
+	defer cleanup()
+
+	c := Schedule("Day", reporter, start, 1)
+
+	g := gantt{Left: margin + TextWidth("Mon "+util.DateFormat) + 10, Top: titleHeight + hourAxisHeight, HourWidth: 40, RowHeight: 40}
+	proj := reporter.AllProjects["p1"]
+	rects, _ := shapesOf(c)
+	assert.Equal(t, []rect{
+		{X: g.Left, Y: g.y(0), W: 80, H: 40, Fill: ProjectColor(&proj), Title: "p1 22:00 - 02:00"},
+	}, rects, "Record from the previous day should be clipped to the day")
+}
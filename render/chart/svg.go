@@ -0,0 +1,40 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SvgRenderer renders a canvas in SVG format
+type SvgRenderer struct {
+	Canvas *Canvas
+}
+
+// Render renders the canvas
+func (r SvgRenderer) Render(w io.Writer) error {
+	c := r.Canvas
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="%.0f">`+"\n", c.Width, c.Height, fontSize)
+
+	if colors := c.hatchColors(); len(colors) > 0 {
+		sb.WriteString("<defs>\n")
+		for _, col := range colors {
+			fmt.Fprintf(
+				&sb, `<pattern id="%s" width="4" height="4" patternUnits="userSpaceOnUse" patternTransform="rotate(45)">`+
+					`<rect width="4" height="4" fill="white"/><rect width="1.5" height="4" fill="%s"/></pattern>`+"\n",
+				hatchID(col), hex(col),
+			)
+		}
+		sb.WriteString("</defs>\n")
+	}
+
+	sb.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+	for _, s := range c.shapes {
+		s.svg(&sb)
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
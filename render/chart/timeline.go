@@ -0,0 +1,165 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	margin      = 10.0
+	titleHeight = 20.0
+	axisWidth   = 40.0
+	axisHeight  = 20.0
+	plotHeight  = 200.0
+	legendRow   = 16.0
+	legendBox   = 10.0
+	minWidth    = 320.0
+)
+
+// Timeline creates a stacked bar chart of the time per project in time bins.
+// Argument labels are the labels of the bins, values are the times per project and bin.
+func Timeline(title string, labels []string, values map[string][]time.Duration, projects map[string]core.Project) *Canvas {
+	numBins := len(labels)
+	step := math.Max(4, math.Min(24, 800/math.Max(1, float64(numBins))))
+	barWidth := math.Max(3, math.Round(step*0.75))
+
+	names := []string{}
+	for _, name := range sortedKeys(values) {
+		for _, v := range values[name] {
+			if v > 0 {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	totals := make([]time.Duration, numBins)
+	for _, name := range names {
+		for i, v := range values[name] {
+			totals[i] += v
+		}
+	}
+	maxTotal := time.Duration(0)
+	for _, t := range totals {
+		if t > maxTotal {
+			maxTotal = t
+		}
+	}
+	gridStep, gridMax := gridSteps(maxTotal)
+
+	width := math.Max(minWidth, axisWidth+float64(numBins)*step+margin)
+	legendRows, legendHeight := legendLayout(names, width)
+	height := titleHeight + plotHeight + axisHeight + legendHeight + margin
+
+	c := NewCanvas(int(width), int(height))
+	c.Text(axisWidth, titleHeight-6, title, TextColor, AnchorStart)
+
+	// Grid and y axis
+	bottom := titleHeight + plotHeight
+	scale := plotHeight / float64(gridMax)
+	for v := time.Duration(0); v <= gridMax; v += gridStep {
+		y := bottom - float64(v)*scale
+		c.Line(axisWidth, y, axisWidth+float64(numBins)*step, y, GridColor)
+		c.Text(axisWidth-4, y+3, fmt.Sprintf("%.0fh", v.Hours()), TextColor, AnchorEnd)
+	}
+
+	// Stacked bars
+	for i := 0; i < numBins; i++ {
+		x := axisWidth + float64(i)*step + (step-barWidth)/2
+		y := bottom
+		for _, name := range names {
+			v := values[name][i]
+			if v <= 0 {
+				continue
+			}
+			h := float64(v) * scale
+			y -= h
+			c.Rect(x, y, barWidth, h, colorOf(name, projects), fmt.Sprintf("%s %s: %s", labels[i], name, util.FormatDuration(v)))
+		}
+	}
+	c.Line(axisWidth, bottom, axisWidth+float64(numBins)*step, bottom, AxisColor)
+
+	// X axis labels, thinned out to avoid overlaps
+	labelWidth := 0.0
+	for _, l := range labels {
+		labelWidth = math.Max(labelWidth, TextWidth(l)+8)
+	}
+	every := int(math.Ceil(labelWidth / step))
+	for i := 0; i < numBins; i += every {
+		c.Text(axisWidth+(float64(i)+0.5)*step, bottom+14, labels[i], TextColor, AnchorMiddle)
+	}
+
+	drawLegend(c, legendRows, projects, bottom+axisHeight+4)
+	return c
+}
+
+// gridSteps returns the step and maximum of horizontal grid lines, in full hours
+func gridSteps(max time.Duration) (time.Duration, time.Duration) {
+	step := time.Hour
+	for _, s := range []int{1, 2, 4, 8, 12, 24, 48, 96, 168, 336, 720} {
+		step = time.Duration(s) * time.Hour
+		if max <= 5*step {
+			break
+		}
+	}
+	steps := int(math.Ceil(float64(max) / float64(step)))
+	if steps < 1 {
+		steps = 1
+	}
+	return step, time.Duration(steps) * step
+}
+
+// legendLayout distributes legend entries over rows that fit the given width
+func legendLayout(names []string, width float64) ([][]string, float64) {
+	rows := [][]string{}
+	row := []string{}
+	x := axisWidth
+	for _, name := range names {
+		w := legendBox + 4 + TextWidth(name) + 12
+		if len(row) > 0 && x+w > width-margin {
+			rows = append(rows, row)
+			row = []string{}
+			x = axisWidth
+		}
+		row = append(row, name)
+		x += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows, float64(len(rows)) * legendRow
+}
+
+// drawLegend draws legend rows of project colors and names, starting at y
+func drawLegend(c *Canvas, rows [][]string, projects map[string]core.Project, y float64) {
+	for _, row := range rows {
+		x := axisWidth
+		for _, name := range row {
+			c.Rect(x, y, legendBox, legendBox, colorOf(name, projects), "")
+			c.Text(x+legendBox+4, y+legendBox-1, name, TextColor, AnchorStart)
+			x += legendBox + 4 + TextWidth(name) + 12
+		}
+		y += legendRow
+	}
+}
+
+// colorOf returns the color of a project, or grey if the project is unknown
+func colorOf(name string, projects map[string]core.Project) color.RGBA {
+	if p, ok := projects[name]; ok {
+		return ProjectColor(&p)
+	}
+	return AxisColor
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
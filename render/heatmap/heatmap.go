@@ -0,0 +1,109 @@
+package heatmap
+
+import (
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+)
+
+// grid holds the days of a heatmap, arranged in weeks (columns) and weekdays (rows)
+type grid struct {
+	Start  time.Time
+	Days   int
+	Weeks  int
+	Totals []time.Duration
+	Max    time.Duration
+	Offset int
+	Cal    util.Calendar
+}
+
+// newGrid creates a grid from a reporter, for days between start and the exclusive end.
+// Argument max is the duration of a "full" day. If it is zero, the maximum of all days is used.
+func newGrid(r *core.Reporter, start, end time.Time, max time.Duration) grid {
+	start = util.ToDate(start)
+	days := 0
+	for util.AddDays(start, days).Before(end) {
+		days++
+	}
+	totals := r.DayTotals(start, days)
+
+	if max <= 0 {
+		for _, t := range totals {
+			if t > max {
+				max = t
+			}
+		}
+	}
+
+	cal := r.Track.Config.Calendar()
+	offset := cal.WeekdayIndex(start.Weekday())
+	return grid{
+		Start:  start,
+		Days:   days,
+		Weeks:  (offset + days + 6) / 7,
+		Totals: totals,
+		Max:    max,
+		Offset: offset,
+		Cal:    cal,
+	}
+}
+
+// Index returns the day index for a week and weekday row, and whether it is inside the range
+func (g *grid) Index(week, row int) (int, bool) {
+	idx := week*7 + row - g.Offset
+	return idx, idx >= 0 && idx < g.Days
+}
+
+// Value returns the value of a day, scaled to the range [0, 1]
+func (g *grid) Value(idx int) float64 {
+	if g.Max <= 0 {
+		return 0
+	}
+	v := float64(g.Totals[idx]) / float64(g.Max)
+	if v > 1 {
+		v = 1
+	}
+	return v
+}
+
+// Date returns the date of a day
+func (g *grid) Date(idx int) time.Time {
+	return util.AddDays(g.Start, idx)
+}
+
+type monthLabel struct {
+	Week  int
+	Label string
+}
+
+// MonthLabels returns the week columns of the first days of months in the range.
+// The label for the start of the range is omitted if it would overlap the next label.
+func (g *grid) MonthLabels() []monthLabel {
+	labels := []monthLabel{}
+	for idx := 0; idx < g.Days; idx++ {
+		date := g.Date(idx)
+		if date.Day() != 1 && idx != 0 {
+			continue
+		}
+		label := monthLabel{Week: (idx + g.Offset) / 7, Label: date.Format("Jan")}
+		if len(labels) == 1 && labels[0].Week+4 > label.Week {
+			labels = labels[:0]
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// Total returns the total time, and the number of days with time
+func (g *grid) Total() (time.Duration, int) {
+	total := time.Duration(0)
+	days := 0
+	for _, t := range g.Totals {
+		total += t
+		if t > 0 {
+			days++
+		}
+	}
+	return total, days
+}
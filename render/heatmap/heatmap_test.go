@@ -0,0 +1,163 @@
+package heatmap
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupReporter(t *testing.T, weekStart string, start, end time.Time) (*core.Reporter, func()) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	track, err := core.NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+	track.Config.WeekStart = weekStart
+
+	if err := track.SaveProject(core.NewProject("p1", "", "p", []string{}, 15, 1), false); err != nil {
+		t.Fatal("error saving project")
+	}
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 28, 10, 0, 0), End: util.DateTime(2023, 3, 28, 12, 0, 0)},
+		{Project: "p1", Start: util.DateTime(2023, 4, 2, 10, 0, 0), End: util.DateTime(2023, 4, 2, 11, 0, 0)},
+	}
+	for _, rec := range records {
+		if err := track.SaveRecord(&rec, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err := core.NewReporter(
+		&track, []string{}, core.NewFilter([]core.FilterFunction{}, start, end),
+		false, start, end,
+	)
+	if err != nil {
+		t.Fatalf("error creating reporter: %s", err)
+	}
+	return reporter, func() { os.RemoveAll(dir) }
+}
+
+func TestGridIndex(t *testing.T) {
+	start, end := util.Date(2023, 3, 28), util.Date(2023, 4, 10)
+
+	type cell struct {
+		Week, Row int
+		Index     int
+		Ok        bool
+		Weekday   time.Weekday
+	}
+	tt := []struct {
+		Title     string
+		WeekStart string
+		Offset    int
+		Weeks     int
+		Cells     []cell
+	}{
+		{
+			Title: "monday", WeekStart: "monday", Offset: 1, Weeks: 2,
+			Cells: []cell{
+				{Week: 0, Row: 0, Index: -1, Ok: false, Weekday: time.Monday},
+				{Week: 0, Row: 1, Index: 0, Ok: true, Weekday: time.Tuesday},
+				{Week: 0, Row: 6, Index: 5, Ok: true, Weekday: time.Sunday},
+				{Week: 1, Row: 6, Index: 12, Ok: true, Weekday: time.Sunday},
+			},
+		},
+		{
+			Title: "sunday", WeekStart: "sunday", Offset: 2, Weeks: 3,
+			Cells: []cell{
+				{Week: 0, Row: 1, Index: -1, Ok: false, Weekday: time.Monday},
+				{Week: 0, Row: 2, Index: 0, Ok: true, Weekday: time.Tuesday},
+				{Week: 1, Row: 0, Index: 5, Ok: true, Weekday: time.Sunday},
+				{Week: 2, Row: 0, Index: 12, Ok: true, Weekday: time.Sunday},
+				{Week: 2, Row: 1, Index: 13, Ok: false, Weekday: time.Monday},
+			},
+		},
+		{
+			Title: "wednesday", WeekStart: "wednesday", Offset: 6, Weeks: 3,
+			Cells: []cell{
+				{Week: 0, Row: 5, Index: -1, Ok: false, Weekday: time.Monday},
+				{Week: 0, Row: 6, Index: 0, Ok: true, Weekday: time.Tuesday},
+				{Week: 1, Row: 4, Index: 5, Ok: true, Weekday: time.Sunday},
+				{Week: 2, Row: 5, Index: 13, Ok: false, Weekday: time.Monday},
+			},
+		},
+	}
+
+	for _, test := range tt {
+		reporter, cleanup := setupReporter(t, test.WeekStart, start, end)
+
+		g := newGrid(reporter, start, end, 0)
+		assert.Equal(t, 13, g.Days, "Wrong number of days in test %s", test.Title)
+		assert.Equal(t, test.Offset, g.Offset, "Wrong offset in test %s", test.Title)
+		assert.Equal(t, test.Weeks, g.Weeks, "Wrong number of weeks in test %s", test.Title)
+		assert.Equal(t, 2*time.Hour, g.Max, "Wrong maximum in test %s", test.Title)
+
+		for _, c := range test.Cells {
+			idx, ok := g.Index(c.Week, c.Row)
+			assert.Equal(t, c.Index, idx, "Wrong index for %d/%d in test %s", c.Week, c.Row, test.Title)
+			assert.Equal(t, c.Ok, ok, "Wrong in-range flag for %d/%d in test %s", c.Week, c.Row, test.Title)
+			assert.Equal(t, c.Weekday, g.Cal.Weekday(c.Row), "Wrong weekday for row %d in test %s", c.Row, test.Title)
+			if ok {
+				assert.Equal(t, c.Weekday, g.Date(idx).Weekday(), "Wrong date for %d/%d in test %s", c.Week, c.Row, test.Title)
+			}
+		}
+
+		cleanup()
+	}
+}
+
+func TestMonthLabels(t *testing.T) {
+	tt := []struct {
+		Title      string
+		Start, End time.Time
+		Labels     []monthLabel
+	}{
+		{
+			Title: "late start", Start: util.Date(2023, 3, 28), End: util.Date(2023, 5, 1),
+			Labels: []monthLabel{{Week: 0, Label: "Apr"}},
+		},
+		{
+			Title: "late start, long range", Start: util.Date(2023, 3, 28), End: util.Date(2023, 5, 10),
+			Labels: []monthLabel{{Week: 0, Label: "Apr"}, {Week: 5, Label: "May"}},
+		},
+		{
+			Title: "early start", Start: util.Date(2023, 3, 1), End: util.Date(2023, 5, 1),
+			Labels: []monthLabel{{Week: 0, Label: "Mar"}, {Week: 4, Label: "Apr"}},
+		},
+	}
+
+	for _, test := range tt {
+		reporter, cleanup := setupReporter(t, "sunday", test.Start, test.End)
+
+		g := newGrid(reporter, test.Start, test.End, 0)
+		assert.Equal(t, test.Labels, g.MonthLabels(), "Wrong labels in test %s", test.Title)
+
+		cleanup()
+	}
+}
+
+func TestTextRenderer(t *testing.T) {
+	start, end := util.Date(2023, 3, 28), util.Date(2023, 4, 10)
+	reporter, cleanup := setupReporter(t, "sunday", start, end)
+	defer cleanup()
+
+	buffer := bytes.Buffer{}
+	err := TextRenderer{Reporter: reporter, Start: start, End: end}.Render(&buffer)
+	assert.Nil(t, err)
+
+	lines := strings.Split(buffer.String(), "\n")
+	assert.Equal(t, 11, len(lines), "Wrong number of lines")
+	assert.Equal(t, "   Apr", lines[0], "Wrong month header")
+	assert.True(t, strings.HasPrefix(lines[1], "Su  "), "First row should be Sunday")
+	assert.True(t, strings.HasPrefix(lines[3], "Tu █"), "Expected full block for maximum day")
+	assert.Equal(t, "2023-03-28 - 2023-04-09: 03:00 on 2 of 13 days, █ = 02:00", lines[9], "Wrong summary")
+}
@@ -0,0 +1,99 @@
+package heatmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+)
+
+const (
+	svgPadding    = 30.0
+	svgLabelSpace = 20.0
+)
+
+// SvgOptions are options for SVG heatmap rendering
+type SvgOptions struct {
+	CellSize float64
+	Gap      float64
+	Color    string
+}
+
+// SvgRenderer renders a calendar heatmap in SVG format
+type SvgRenderer struct {
+	Reporter *core.Reporter
+	Start    time.Time
+	End      time.Time
+	Max      time.Duration
+	Options  SvgOptions
+}
+
+// Render renders the heatmap
+func (r SvgRenderer) Render(w io.Writer) error {
+	g := newGrid(r.Reporter, r.Start, r.End, r.Max)
+
+	var red, green, blue uint8
+	if _, err := fmt.Sscanf(r.Options.Color, "#%02x%02x%02x", &red, &green, &blue); err != nil {
+		return fmt.Errorf("invalid color '%s', expected format #rrggbb", r.Options.Color)
+	}
+
+	step := r.Options.CellSize + r.Options.Gap
+	width := svgPadding + float64(g.Weeks)*step
+	height := svgLabelSpace + 7*step + svgLabelSpace
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" font-family="sans-serif" font-size="10">`+"\n", width, height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+
+	for _, label := range g.MonthLabels() {
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" fill="#666">%s</text>`+"\n", svgPadding+float64(label.Week)*step, svgLabelSpace-6, label.Label)
+	}
+	for row := 0; row < 7; row += 2 {
+		weekday := g.Cal.Weekday(row)
+		fmt.Fprintf(&sb, `<text x="2" y="%.1f" fill="#666">%s</text>`+"\n", svgLabelSpace+float64(row)*step+r.Options.CellSize-1, weekday.String()[:3])
+	}
+
+	for week := 0; week < g.Weeks; week++ {
+		for row := 0; row < 7; row++ {
+			idx, ok := g.Index(week, row)
+			if !ok {
+				continue
+			}
+			v := g.Value(idx)
+			fill := "#ebedf0"
+			if g.Totals[idx] > 0 {
+				// Interpolate from light grey to the base color, with a minimum intensity
+				v = 0.2 + 0.8*v
+				fill = fmt.Sprintf(
+					"#%02x%02x%02x",
+					interpolate(0xeb, red, v), interpolate(0xed, green, v), interpolate(0xf0, blue, v),
+				)
+			}
+			fmt.Fprintf(
+				&sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="2" fill="%s"><title>%s: %s</title></rect>`+"\n",
+				svgPadding+float64(week)*step, svgLabelSpace+float64(row)*step,
+				r.Options.CellSize, r.Options.CellSize, fill,
+				g.Date(idx).Format("Mon "+util.DateFormat), util.FormatDuration(g.Totals[idx]),
+			)
+		}
+	}
+
+	total, days := g.Total()
+	fmt.Fprintf(
+		&sb, `<text x="%.1f" y="%.1f" fill="#666">%s - %s: %s on %d of %d days</text>`+"\n",
+		svgPadding, height-6,
+		g.Start.Format(util.DateFormat), g.Date(g.Days-1).Format(util.DateFormat),
+		util.FormatDuration(total), days, g.Days,
+	)
+	fmt.Fprintf(&sb, "</svg>\n")
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+func interpolate(from, to uint8, v float64) uint8 {
+	return uint8(float64(from) + (float64(to)-float64(from))*v)
+}
@@ -0,0 +1,67 @@
+package heatmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+)
+
+// TextRenderer renders a calendar heatmap for the terminal
+type TextRenderer struct {
+	Reporter *core.Reporter
+	Start    time.Time
+	End      time.Time
+	Max      time.Duration
+	Space    *rune
+}
+
+// Render renders the heatmap
+func (r TextRenderer) Render(w io.Writer) error {
+	g := newGrid(r.Reporter, r.Start, r.End, r.Max)
+
+	labels := g.MonthLabels()
+	header := []rune(strings.Repeat(" ", g.Weeks+3))
+	for _, label := range labels {
+		for i, c := range label.Label {
+			if label.Week+i < g.Weeks {
+				header[3+label.Week+i] = c
+			}
+		}
+	}
+
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "%s\n", strings.TrimRight(string(header), " "))
+	for row := 0; row < 7; row++ {
+		weekday := g.Cal.Weekday(row)
+		fmt.Fprintf(&sb, "%s ", weekday.String()[:2])
+		for week := 0; week < g.Weeks; week++ {
+			idx, ok := g.Index(week, row)
+			if !ok {
+				fmt.Fprint(&sb, " ")
+				continue
+			}
+			block := util.FloatToBlock(g.Value(idx), r.Space)
+			if g.Totals[idx] > 0 && block == util.FloatToBlock(0, r.Space) {
+				// Distinguish days with little time from days without time
+				block = util.BlockRunes[1]
+			}
+			fmt.Fprintf(&sb, "%c", block)
+		}
+		fmt.Fprint(&sb, "\n")
+	}
+
+	total, days := g.Total()
+	fmt.Fprintf(
+		&sb, "\n%s - %s: %s on %d of %d days, %c = %s\n",
+		g.Start.Format(util.DateFormat), g.Date(g.Days-1).Format(util.DateFormat),
+		util.FormatDuration(total), days, g.Days,
+		util.BlockRunes[len(util.BlockRunes)-1], util.FormatDuration(g.Max),
+	)
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
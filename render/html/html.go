@@ -0,0 +1,331 @@
+package html
+
+import (
+	_ "embed" // for embedding the HTML template
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"golang.org/x/exp/maps"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+const (
+	timelineWidth  = 960.0
+	timelineHeight = 240.0
+	timelineMargin = 40.0
+	defaultColor   = "#888888"
+)
+
+// Renderer renders a self-contained HTML report with charts and tables
+type Renderer struct {
+	Reporter *core.Reporter
+	Title    string
+}
+
+type treeNode struct {
+	Name     string      `json:"name"`
+	Color    string      `json:"color"`
+	Minutes  float64     `json:"minutes"`
+	Own      float64     `json:"own"`
+	Children []*treeNode `json:"children,omitempty"`
+}
+
+type reportData struct {
+	Title     string
+	Workspace string
+	Range     string
+	Total     string
+	Generated string
+	Tree      template.JS
+	Timeline  timeline
+	Tags      []tagRow
+	Records   []recordRow
+}
+
+type timeline struct {
+	Width   float64
+	Height  float64
+	Unit    string
+	Bars    []timelineBar
+	Labels  []timelineLabel
+	Legend  []legendEntry
+	AxisMax string
+}
+
+type timelineBar struct {
+	X, Y, W, H float64
+	Color      string
+	Title      string
+}
+
+type timelineLabel struct {
+	X    float64
+	Text string
+}
+
+type legendEntry struct {
+	Name  string
+	Color string
+}
+
+type tagRow struct {
+	Tag      string
+	Count    int
+	Work     string
+	WorkMin  int64
+	Pause    string
+	PauseMin int64
+	Values   string
+}
+
+type recordRow struct {
+	Project  string
+	Color    string
+	Start    string
+	End      string
+	Duration string
+	DurMin   int64
+	Pause    string
+	PauseMin int64
+	Tags     string
+	Note     string
+}
+
+// Render renders the report
+func (r Renderer) Render(w io.Writer) error {
+	tmpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return err
+	}
+
+	tree, err := json.Marshal(r.tree(r.Reporter.ProjectsTree.Root))
+	if err != nil {
+		return err
+	}
+
+	bounds := r.Reporter.Bounds()
+	rangeStr := "-"
+	if !bounds.Start.IsZero() {
+		rangeStr = fmt.Sprintf(
+			"%s - %s",
+			bounds.Start.Format(util.DateFormat),
+			bounds.End.Add(-time.Second).Format(util.DateFormat),
+		)
+	}
+
+	data := reportData{
+		Title:     r.Title,
+		Workspace: r.Reporter.Track.Workspace(),
+		Range:     rangeStr,
+		Total:     util.FormatDuration(r.Reporter.TotalTime[r.Reporter.ProjectsTree.Root.Value.Name]),
+		Generated: time.Now().Format(util.DateTimeFormat),
+		Tree:      template.JS(tree),
+		Timeline:  r.timeline(bounds),
+		Tags:      r.tags(),
+		Records:   r.records(),
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+// tree converts the project tree to a JSON-serializable tree, with times in minutes.
+// Projects without time are omitted.
+func (r *Renderer) tree(node *core.ProjectNode) *treeNode {
+	res := &treeNode{
+		Name:    node.Value.Name,
+		Color:   projectColor(node.Value),
+		Minutes: r.Reporter.TotalTime[node.Value.Name].Minutes(),
+		Own:     r.Reporter.ProjectTime[node.Value.Name].Minutes(),
+	}
+	if node.Parent == nil {
+		res.Color = defaultColor
+	}
+	for _, child := range node.Children {
+		if r.Reporter.TotalTime[child.Value.Name] <= 0 {
+			continue
+		}
+		res.Children = append(res.Children, r.tree(child))
+	}
+	sort.Slice(res.Children, func(i, j int) bool {
+		return res.Children[i].Minutes > res.Children[j].Minutes
+	})
+	return res
+}
+
+// timeline calculates stacked bars of time per project and day, week or month
+func (r *Renderer) timeline(bounds core.TimeRange) timeline {
+	tl := timeline{
+		Width:  timelineWidth,
+		Height: timelineHeight,
+	}
+	if bounds.Start.IsZero() || len(r.Reporter.Records) == 0 {
+		return tl
+	}
+
+	start := util.ToDate(bounds.Start)
+	days := bounds.End.Sub(start).Hours() / 24
+	var next func(time.Time) time.Time
+	switch {
+	case days <= 62:
+		tl.Unit = "day"
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case days <= 2*366:
+		tl.Unit = "week"
+		start = r.Reporter.Track.Config.Calendar().WeekStart(start)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	default:
+		tl.Unit = "month"
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.Local)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	}
+
+	bins := []time.Time{start}
+	for bins[len(bins)-1].Before(bounds.End) {
+		bins = append(bins, next(bins[len(bins)-1]))
+	}
+	numBins := len(bins) - 1
+
+	values := map[string][]time.Duration{}
+	for _, rec := range r.Reporter.Records {
+		idx := sort.Search(len(bins), func(i int) bool { return bins[i].After(rec.Start) }) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		end := rec.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		for ; idx < numBins && bins[idx].Before(end); idx++ {
+			d := rec.Duration(bins[idx], bins[idx+1])
+			if d <= 0 {
+				continue
+			}
+			if _, ok := values[rec.Project]; !ok {
+				values[rec.Project] = make([]time.Duration, numBins)
+			}
+			values[rec.Project][idx] += d
+		}
+	}
+
+	projects := maps.Keys(values)
+	sort.Strings(projects)
+
+	totals := make([]time.Duration, numBins)
+	maxTotal := time.Duration(0)
+	for _, p := range projects {
+		for i, v := range values[p] {
+			totals[i] += v
+			if totals[i] > maxTotal {
+				maxTotal = totals[i]
+			}
+		}
+	}
+	if maxTotal == 0 {
+		return tl
+	}
+	tl.AxisMax = util.FormatDuration(maxTotal)
+
+	plotHeight := timelineHeight - timelineMargin
+	barWidth := timelineWidth / float64(numBins)
+	labelStep := numBins/12 + 1
+	offsets := make([]float64, numBins)
+	for _, p := range projects {
+		col := projectColor(r.Reporter.AllProjects[p])
+		tl.Legend = append(tl.Legend, legendEntry{Name: p, Color: col})
+		for i, v := range values[p] {
+			if v <= 0 {
+				continue
+			}
+			h := plotHeight * float64(v) / float64(maxTotal)
+			offsets[i] += h
+			tl.Bars = append(tl.Bars, timelineBar{
+				X:     float64(i)*barWidth + 1,
+				Y:     plotHeight - offsets[i],
+				W:     barWidth - 2,
+				H:     h,
+				Color: col,
+				Title: fmt.Sprintf("%s %s: %s", bins[i].Format(util.DateFormat), p, util.FormatDuration(v)),
+			})
+		}
+	}
+	for i := 0; i < numBins; i += labelStep {
+		tl.Labels = append(tl.Labels, timelineLabel{
+			X:    float64(i)*barWidth + 1,
+			Text: bins[i].Format("01-02"),
+		})
+	}
+
+	return tl
+}
+
+// tags calculates statistics for all tags
+func (r *Renderer) tags() []tagRow {
+	stats := r.Reporter.TagStats(nil)
+	keys := maps.Keys(stats)
+	sort.Strings(keys)
+
+	rows := make([]tagRow, 0, len(keys))
+	for _, tag := range keys {
+		st := stats[tag]
+		values := []string{}
+		for v, vs := range st.Values {
+			if v == "" {
+				continue
+			}
+			values = append(values, fmt.Sprintf("%s (%s)", v, util.FormatDuration(vs.Work)))
+		}
+		sort.Strings(values)
+		rows = append(rows, tagRow{
+			Tag:      tag,
+			Count:    st.Count,
+			Work:     util.FormatDuration(st.Work),
+			WorkMin:  int64(st.Work.Minutes()),
+			Pause:    util.FormatDuration(st.Pause),
+			PauseMin: int64(st.Pause.Minutes()),
+			Values:   strings.Join(values, ", "),
+		})
+	}
+	return rows
+}
+
+// records creates table rows for all records
+func (r *Renderer) records() []recordRow {
+	rows := make([]recordRow, 0, len(r.Reporter.Records))
+	for _, rec := range r.Reporter.Records {
+		end := "running"
+		if rec.HasEnded() {
+			end = rec.End.Format(util.DateTimeFormat)
+		}
+		tags := maps.Keys(rec.Tags)
+		sort.Strings(tags)
+		dur := rec.Duration(util.NoTime, util.NoTime)
+		pause := rec.PauseDuration(util.NoTime, util.NoTime)
+		rows = append(rows, recordRow{
+			Project:  rec.Project,
+			Color:    projectColor(r.Reporter.AllProjects[rec.Project]),
+			Start:    rec.Start.Format(util.DateTimeFormat),
+			End:      end,
+			Duration: util.FormatDuration(dur),
+			DurMin:   int64(dur.Minutes()),
+			Pause:    util.FormatDuration(pause),
+			PauseMin: int64(pause.Minutes()),
+			Tags:     strings.Join(tags, " "),
+			Note:     strings.ReplaceAll(rec.Note, "\n", " "),
+		})
+	}
+	return rows
+}
+
+// projectColor returns the hex color of a project's background color
+func projectColor(p core.Project) string {
+	return p.Color.Hex()
+}
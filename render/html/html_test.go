@@ -0,0 +1,126 @@
+package html
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupReporter(t *testing.T) (*core.Reporter, func()) {
+	dir, err := os.MkdirTemp("", "track-test")
+	if err != nil {
+		t.Fatal("error creating temporary directory")
+	}
+	track, err := core.NewTrack(&dir)
+	if err != nil {
+		t.Fatal("error creating Track instance")
+	}
+
+	for i, name := range []string{"p1", "p2"} {
+		if err := track.SaveProject(core.NewProject(name, "", "p", []string{}, 15, uint8(i+1)), false); err != nil {
+			t.Fatal("error saving project")
+		}
+	}
+	records := []core.Record{
+		{Project: "p1", Start: util.DateTime(2023, 3, 1, 23, 0, 0), End: util.DateTime(2023, 3, 2, 1, 0, 0), Note: "Late +foo", Tags: map[string]string{"foo": ""}},
+		{Project: "p2", Start: util.DateTime(2023, 3, 2, 10, 0, 0), End: util.DateTime(2023, 3, 2, 11, 0, 0), Note: "Line 1\nLine 2"},
+	}
+	for _, rec := range records {
+		if err := track.SaveRecord(&rec, false); err != nil {
+			t.Fatal("error saving record")
+		}
+	}
+
+	reporter, err := core.NewReporter(
+		&track, []string{}, core.NewFilter([]core.FilterFunction{}, util.Date(2023, 3, 1), util.Date(2023, 3, 3)),
+		false, util.Date(2023, 3, 1), util.Date(2023, 3, 3),
+	)
+	if err != nil {
+		t.Fatalf("error creating reporter: %s", err)
+	}
+	return reporter, func() { os.RemoveAll(dir) }
+}
+
+func TestTree(t *testing.T) {
+	reporter, cleanup := setupReporter(t)
+	defer cleanup()
+
+	r := Renderer{Reporter: reporter}
+	bytes, err := json.Marshal(r.tree(reporter.ProjectsTree.Root))
+	assert.Nil(t, err)
+
+	var root treeNode
+	assert.Nil(t, json.Unmarshal(bytes, &root))
+	assert.Equal(t, 180.0, root.Minutes, "Wrong total time")
+	assert.Equal(t, defaultColor, root.Color, "Wrong root color")
+	assert.Equal(t, 2, len(root.Children), "Wrong number of projects")
+	assert.Equal(t, treeNode{Name: "p1", Color: "#800000", Minutes: 120, Own: 120}, *root.Children[0], "Projects should be sorted by time")
+	assert.Equal(t, treeNode{Name: "p2", Color: "#008000", Minutes: 60, Own: 60}, *root.Children[1])
+}
+
+func TestTimeline(t *testing.T) {
+	reporter, cleanup := setupReporter(t)
+	defer cleanup()
+
+	r := Renderer{Reporter: reporter}
+	tl := r.timeline(reporter.Bounds())
+
+	assert.Equal(t, "day", tl.Unit, "Wrong timeline unit")
+	assert.Equal(t, "02:00", tl.AxisMax, "Wrong axis maximum")
+
+	titles := []string{}
+	for _, bar := range tl.Bars {
+		titles = append(titles, bar.Title)
+	}
+	assert.Equal(t, []string{
+		"2023-03-01 p1: 01:00",
+		"2023-03-02 p1: 01:00",
+		"2023-03-02 p2: 01:00",
+	}, titles, "Record crossing midnight should be split at the bin boundary")
+
+	assert.Equal(t, []timelineLabel{{X: 1, Text: "03-01"}, {X: timelineWidth/2 + 1, Text: "03-02"}}, tl.Labels, "Wrong labels")
+	assert.Equal(t, []legendEntry{{Name: "p1", Color: "#800000"}, {Name: "p2", Color: "#008000"}}, tl.Legend, "Wrong legend")
+}
+
+func TestRecords(t *testing.T) {
+	reporter, cleanup := setupReporter(t)
+	defer cleanup()
+
+	r := Renderer{Reporter: reporter}
+	assert.Equal(t, []recordRow{
+		{
+			Project: "p1", Color: "#800000",
+			Start: "2023-03-01 23:00", End: "2023-03-02 01:00",
+			Duration: "02:00", DurMin: 120, Pause: "00:00",
+			Tags: "foo", Note: "Late +foo",
+		},
+		{
+			Project: "p2", Color: "#008000",
+			Start: "2023-03-02 10:00", End: "2023-03-02 11:00",
+			Duration: "01:00", DurMin: 60, Pause: "00:00",
+			Note: "Line 1 Line 2",
+		},
+	}, r.records(), "Wrong record rows")
+}
+
+func TestRender(t *testing.T) {
+	reporter, cleanup := setupReporter(t)
+	defer cleanup()
+
+	buffer := bytes.Buffer{}
+	err := Renderer{Reporter: reporter, Title: "Test report"}.Render(&buffer)
+	assert.Nil(t, err)
+
+	got := buffer.String()
+	assert.True(t, strings.HasPrefix(got, "<!DOCTYPE html>"), "Expected an HTML document")
+	assert.Contains(t, got, "Test report")
+	assert.Contains(t, got, "2023-03-01 - 2023-03-02", "Wrong report range")
+	assert.Contains(t, got, `"name":"p1","color":"#800000","minutes":120,"own":120`, "Tree JSON should be embedded")
+	assert.Contains(t, got, `<td class="num" data-sort="120">02:00</td>`, "Missing record row")
+}
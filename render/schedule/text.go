@@ -8,8 +8,8 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"github.com/gookit/color"
 	"github.com/mlange-42/track/core"
+	"github.com/mlange-42/track/out"
 	"github.com/mlange-42/track/util"
 	"golang.org/x/exp/maps"
 )
@@ -35,9 +35,9 @@ func (r *TextRenderer) Render(w io.Writer) error {
 	sort.Strings(projects)
 	indices := make(map[string]int, len(projects))
 	symbols := make([]rune, len(projects)+1)
-	colors := make([]color.Style256, len(projects)+1)
+	colors := make([]out.Style, len(projects)+1)
 	symbols[0] = spaceSym
-	colors[0] = *color.S256(15, 0)
+	colors[0] = out.NewStyle(util.PaletteColor(15), util.PaletteColor(0))
 	for i, p := range projects {
 		indices[p] = i + 1
 		symbols[i+1] = []rune(r.Reporter.Projects[p].Symbol)[0]
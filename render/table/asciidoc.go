@@ -0,0 +1,54 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// AsciiDocRenderer renders a table in AsciiDoc format
+type AsciiDocRenderer struct {
+	Table *Table
+}
+
+// Render renders the table
+func (r AsciiDocRenderer) Render(w io.Writer) error {
+	t := escapeTable(r.Table, strings.NewReplacer("|", "\\|", "\n", " "))
+	cols := t.Columns()
+
+	specs := make([]string, cols)
+	for i := range specs {
+		if t.alignRight(i) {
+			specs[i] = ">"
+		} else {
+			specs[i] = "<"
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("[cols=\"" + strings.Join(specs, ",") + "\"")
+	if len(t.Header) > 0 {
+		sb.WriteString(",options=\"header\"")
+	}
+	sb.WriteString("]\n|===\n")
+
+	writeRow := func(row []string) {
+		for i := 0; i < cols; i++ {
+			if i > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString("|" + cell(row, i))
+		}
+		sb.WriteString("\n")
+	}
+	if len(t.Header) > 0 {
+		writeRow(t.Header)
+		sb.WriteString("\n")
+	}
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+	sb.WriteString("|===\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
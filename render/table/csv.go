@@ -0,0 +1,25 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CsvRenderer renders a table in CSV format
+type CsvRenderer struct {
+	Table *Table
+}
+
+// Render renders the table
+func (r CsvRenderer) Render(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if len(r.Table.Header) > 0 {
+		if err := writer.Write(r.Table.Header); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteAll(r.Table.Rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
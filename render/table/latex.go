@@ -0,0 +1,65 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// latexEscape escapes characters with special meaning in LaTeX
+var latexEscape = strings.NewReplacer(
+	"\\", "\\textbackslash{}",
+	"&", "\\&",
+	"%", "\\%",
+	"$", "\\$",
+	"#", "\\#",
+	"_", "\\_",
+	"{", "\\{",
+	"}", "\\}",
+	"~", "\\textasciitilde{}",
+	"^", "\\textasciicircum{}",
+	"<", "\\textless{}",
+	">", "\\textgreater{}",
+	"\n", " ",
+)
+
+// LatexRenderer renders a table as a LaTeX tabular environment
+type LatexRenderer struct {
+	Table *Table
+}
+
+// Render renders the table
+func (r LatexRenderer) Render(w io.Writer) error {
+	t := escapeTable(r.Table, latexEscape)
+	cols := t.Columns()
+
+	spec := strings.Builder{}
+	for i := 0; i < cols; i++ {
+		if t.alignRight(i) {
+			spec.WriteString("r")
+		} else {
+			spec.WriteString("l")
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("\\begin{tabular}{" + spec.String() + "}\n\\hline\n")
+
+	writeRow := func(row []string) {
+		cells := make([]string, cols)
+		for i := range cells {
+			cells[i] = cell(row, i)
+		}
+		sb.WriteString(strings.Join(cells, " & ") + " \\\\\n")
+	}
+	if len(t.Header) > 0 {
+		writeRow(t.Header)
+		sb.WriteString("\\hline\n")
+	}
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+	sb.WriteString("\\hline\n\\end{tabular}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,64 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// MarkdownRenderer renders a table in Markdown (GitHub flavored) format
+type MarkdownRenderer struct {
+	Table *Table
+}
+
+// Render renders the table
+func (r MarkdownRenderer) Render(w io.Writer) error {
+	t := escapeTable(r.Table, strings.NewReplacer("|", "\\|", "<", "\\<", "\n", " "))
+	widths := t.widths(3)
+
+	sb := strings.Builder{}
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i, width := range widths {
+			sb.WriteString(" " + pad(cell(row, i), width, t.alignRight(i)) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Markdown tables require a header row
+	writeRow(t.Header)
+	sb.WriteString("|")
+	for i, width := range widths {
+		if t.alignRight(i) {
+			sb.WriteString(" " + strings.Repeat("-", width-1) + ": |")
+		} else {
+			sb.WriteString(" " + strings.Repeat("-", width) + " |")
+		}
+	}
+	sb.WriteString("\n")
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// escapeTable returns a copy of a table with all cells escaped
+func escapeTable(t *Table, replacer *strings.Replacer) *Table {
+	escape := func(row []string) []string {
+		result := make([]string, len(row))
+		for i, c := range row {
+			result[i] = replacer.Replace(c)
+		}
+		return result
+	}
+	escaped := Table{
+		Header:     escape(t.Header),
+		Rows:       make([][]string, len(t.Rows)),
+		AlignRight: t.AlignRight,
+	}
+	for i, row := range t.Rows {
+		escaped.Rows[i] = escape(row)
+	}
+	return &escaped
+}
@@ -0,0 +1,115 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mlange-42/track/render"
+)
+
+// Table formats
+const (
+	Text     = "text"
+	Csv      = "csv"
+	Markdown = "markdown"
+	AsciiDoc = "asciidoc"
+	Latex    = "latex"
+)
+
+// Formats are all available table formats
+var Formats = []string{Text, Csv, Markdown, AsciiDoc, Latex}
+
+// Table is a table of strings, with an optional header row
+type Table struct {
+	Header []string
+	Rows   [][]string
+	// Columns aligned to the right, like durations
+	AlignRight []bool
+}
+
+// New creates a new table with the given header
+func New(header ...string) *Table {
+	return &Table{
+		Header:     header,
+		Rows:       [][]string{},
+		AlignRight: make([]bool, len(header)),
+	}
+}
+
+// Append appends a row to the table
+func (t *Table) Append(row ...string) {
+	t.Rows = append(t.Rows, row)
+}
+
+// Columns returns the number of columns of the table
+func (t *Table) Columns() int {
+	cols := len(t.Header)
+	for _, row := range t.Rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	return cols
+}
+
+// alignRight returns whether a column is aligned to the right
+func (t *Table) alignRight(col int) bool {
+	return col < len(t.AlignRight) && t.AlignRight[col]
+}
+
+// widths returns the display widths of all columns
+func (t *Table) widths(minWidth int) []int {
+	widths := make([]int, t.Columns())
+	for i := range widths {
+		widths[i] = minWidth
+	}
+	for _, row := range append([][]string{t.Header}, t.Rows...) {
+		for i, cell := range row {
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths
+}
+
+// cell returns the cell of a row, or an empty string for short rows
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+// pad pads a string to the given width
+func pad(s string, width int, right bool) string {
+	fill := strings.Repeat(" ", width-utf8.RuneCountInString(s))
+	if right {
+		return fill + s
+	}
+	return s + fill
+}
+
+// NewRenderer creates a renderer for a table in the given format
+func NewRenderer(format string, table *Table) (render.Renderer, error) {
+	switch format {
+	case Text:
+		return TextRenderer{Table: table}, nil
+	case Csv:
+		return CsvRenderer{Table: table}, nil
+	case Markdown:
+		return MarkdownRenderer{Table: table}, nil
+	case AsciiDoc:
+		return AsciiDocRenderer{Table: table}, nil
+	case Latex:
+		return LatexRenderer{Table: table}, nil
+	}
+	return nil, fmt.Errorf("unknown table format '%s', use one of: %s", format, strings.Join(Formats, ", "))
+}
+
+// CheckFormat checks that a table format exists
+func CheckFormat(format string) error {
+	_, err := NewRenderer(format, nil)
+	return err
+}
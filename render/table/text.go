@@ -0,0 +1,35 @@
+package table
+
+import (
+	"io"
+	"strings"
+)
+
+// TextRenderer renders a table as aligned plain text columns
+type TextRenderer struct {
+	Table *Table
+}
+
+// Render renders the table
+func (r TextRenderer) Render(w io.Writer) error {
+	widths := r.Table.widths(0)
+	rows := r.Table.Rows
+	if len(r.Table.Header) > 0 {
+		rows = append([][]string{r.Table.Header}, rows...)
+	}
+
+	sb := strings.Builder{}
+	for _, row := range rows {
+		line := strings.Builder{}
+		for i, width := range widths {
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			line.WriteString(pad(cell(row, i), width, r.Table.alignRight(i)))
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/color"
+)
+
+// Color is a color, either an index in the 256 colors palette, or a true color.
+// Serialized as a number for palette colors, and as a hex string like "#ff8800" for true colors.
+type Color struct {
+	// Index in the 256 colors palette. Ignored for true colors
+	Index uint8
+	// Red, green and blue components of true colors
+	R, G, B uint8
+	// Whether this is a true color
+	True bool
+}
+
+// PaletteColor creates a color from an index in the 256 colors palette
+func PaletteColor(index uint8) Color {
+	return Color{Index: index}
+}
+
+// TrueColor creates a true color from its red, green and blue components
+func TrueColor(r, g, b uint8) Color {
+	return Color{R: r, G: g, B: b, True: true}
+}
+
+// ParseColor parses a color from a palette index like "208", or a hex string like "#ff8800"
+func ParseColor(text string) (Color, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "#") {
+		hex := strings.TrimPrefix(text, "#")
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		value, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return Color{}, fmt.Errorf("invalid hex color '%s', expected format #rrggbb", text)
+		}
+		return TrueColor(uint8(value>>16), uint8(value>>8), uint8(value)), nil
+	}
+	index, err := strconv.ParseUint(text, 10, 8)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid color '%s', expected index 0..255 or hex color #rrggbb", text)
+	}
+	return PaletteColor(uint8(index)), nil
+}
+
+// RGB returns the red, green and blue components of the color.
+// Palette colors are converted to their standard RGB values.
+func (c Color) RGB() (uint8, uint8, uint8) {
+	if c.True {
+		return c.R, c.G, c.B
+	}
+	rgb := color.C256ToRgb(c.Index)
+	return rgb[0], rgb[1], rgb[2]
+}
+
+// Hex returns the color as a hex string like "#ff8800"
+func (c Color) Hex() string {
+	r, g, b := c.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// String returns the palette index for palette colors, and the hex string for true colors
+func (c Color) String() string {
+	if c.True {
+		return c.Hex()
+	}
+	return strconv.Itoa(int(c.Index))
+}
+
+// MarshalYAML serializes palette colors as numbers, and true colors as hex strings
+func (c Color) MarshalYAML() (interface{}, error) {
+	if c.True {
+		return c.Hex(), nil
+	}
+	return c.Index, nil
+}
+
+// UnmarshalYAML de-serializes colors from numbers or hex strings
+func (c *Color) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	col, err := ParseColor(text)
+	if err != nil {
+		return err
+	}
+	*c = col
+	return nil
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseColor(t *testing.T) {
+	tt := []struct {
+		title    string
+		text     string
+		expected Color
+		err      bool
+	}{
+		{title: "palette", text: "208", expected: PaletteColor(208)},
+		{title: "hex", text: "#ff8800", expected: TrueColor(0xff, 0x88, 0x00)},
+		{title: "short hex", text: "#f80", expected: TrueColor(0xff, 0x88, 0x00)},
+		{title: "out of range", text: "256", err: true},
+		{title: "invalid hex", text: "#ff88", err: true},
+		{title: "no color", text: "orange", err: true},
+	}
+
+	for _, test := range tt {
+		col, err := ParseColor(test.text)
+		if test.err {
+			assert.NotNil(t, err, "Expected error in %s", test.title)
+			continue
+		}
+		assert.Nil(t, err, "Unexpected error in %s", test.title)
+		assert.Equal(t, test.expected, col, "Wrong color in %s", test.title)
+	}
+
+	assert.Equal(t, "#ff8700", PaletteColor(208).Hex(), "Wrong hex of palette color")
+	assert.Equal(t, "208", PaletteColor(208).String(), "Wrong string of palette color")
+	assert.Equal(t, "#ff8800", TrueColor(0xff, 0x88, 0x00).String(), "Wrong string of true color")
+}
+
+func TestColorYaml(t *testing.T) {
+	type colors struct {
+		Palette Color
+		Hex     Color
+	}
+	cols := colors{PaletteColor(15), TrueColor(0xff, 0x88, 0x00)}
+
+	bytes, err := yaml.Marshal(&cols)
+	assert.Nil(t, err)
+	assert.Equal(t, "palette: 15\nhex: '#ff8800'\n", string(bytes), "Wrong YAML serialization")
+
+	var result colors
+	assert.Nil(t, yaml.Unmarshal(bytes, &result))
+	assert.Equal(t, cols, result, "Wrong YAML round trip")
+
+	assert.NotNil(t, yaml.Unmarshal([]byte("palette: 300\n"), &result), "Expected error for invalid color")
+}
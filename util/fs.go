@@ -86,3 +86,39 @@ func FindLatests(path string, isDir bool) (string, string, error) {
 
 	return filepath.Join(path, dir.Name()), dir.Name(), nil
 }
+
+// CopyFile copies a file, creating the target directory if required
+func CopyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := CreateDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
+
+// CopyDir copies a directory recursively. Returns the number of copied files.
+func CopyDir(src, dst string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return CreateDir(filepath.Join(dst, rel))
+		}
+		count++
+		return CopyFile(path, filepath.Join(dst, rel))
+	})
+	return count, err
+}
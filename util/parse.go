@@ -2,23 +2,173 @@ package util
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var (
+	relativeDateRegex = regexp.MustCompile(`^([+-]\d+)([dw])$`)
+	isoWeekRegex      = regexp.MustCompile(`^(\d{4})-w(\d{1,2})$`)
+	monthRegex        = regexp.MustCompile(`^(\d{4})-(\d{1,2})$`)
+	yearRegex         = regexp.MustCompile(`^(\d{4})$`)
+	quarterRegex      = regexp.MustCompile(`^(?:(\d{4})-)?q([1-4])$`)
+	periodRegex       = regexp.MustCompile(`^(this|last|next) (week|month|quarter|year|period)$`)
+	longDurationRegex = regexp.MustCompile(`^(\d+)([dw])(.*)$`)
+)
+
 // ParseDate parses a date string
-func ParseDate(text string) (time.Time, error) {
+//
+// Accepts all expressions for single days understood by ParseDateRange.
+// Expressions that describe a range of days, like "2023" or "this week", are rejected.
+func ParseDate(text string, cal Calendar) (time.Time, error) {
+	return parseDate(text, cal, ToDate(time.Now()))
+}
+
+// parseDate parses a date string, relative to the given day
+func parseDate(text string, cal Calendar, today time.Time) (time.Time, error) {
+	start, end, err := parseDateRange(text, cal, today)
+	if err != nil {
+		return NoTime, err
+	}
+	if !AddDays(start, 1).Equal(end) {
+		return NoTime, fmt.Errorf("'%s' is a range of days, expected a single date", text)
+	}
+	return start, nil
+}
+
+// ParseDateRange parses a date or a date range expression.
+// Returns the first day of the range, and the (exclusive) day after the last day.
+//
+// Single days:
+//
+//	2022-12-31, today, tomorrow, yesterday
+//	-3d, +1w        relative to today
+//	friday          the last friday, including today
+//	last friday     the last friday, excluding today
+//
+// Ranges, with weeks and periods according to the calendar:
+//
+//	this week, last month, next quarter, this year
+//	this period     custom period of the calendar, like a fiscal month
+//	2022-W14        ISO week
+//	2022-03         month
+//	2022            year
+//	q1, 2022-Q1     quarter, of the current year if not given
+func ParseDateRange(text string, cal Calendar) (time.Time, time.Time, error) {
+	return parseDateRange(text, cal, ToDate(time.Now()))
+}
+
+// parseDateRange parses a date or a date range expression, relative to the given day
+func parseDateRange(text string, cal Calendar, today time.Time) (time.Time, time.Time, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	if m := periodRegex.FindStringSubmatch(text); m != nil {
+		offset := map[string]int{"this": 0, "last": -1, "next": 1}[m[1]]
+		return cal.PeriodRange(m[2], today, offset)
+	}
+	if m := relativeDateRegex.FindStringSubmatch(text); m != nil {
+		num, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			num *= 7
+		}
+		start := AddDays(today, num)
+		return start, AddDays(start, 1), nil
+	}
+	if m := isoWeekRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		// December 28 is always in the last ISO week of the year
+		_, maxWeek := Date(year, 12, 28).ISOWeek()
+		if week < 1 || week > maxWeek {
+			return NoTime, NoTime, fmt.Errorf("invalid week number in '%s', year %d has %d weeks", text, year, maxWeek)
+		}
+		start := AddDays(Monday(Date(year, 1, 4)), 7*(week-1))
+		return start, AddDays(start, 7), nil
+	}
+	if m := monthRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return NoTime, NoTime, fmt.Errorf("invalid month in '%s'", text)
+		}
+		start := Date(year, time.Month(month), 1)
+		return start, start.AddDate(0, 1, 0), nil
+	}
+	if m := yearRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		start := Date(year, 1, 1)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+	if m := quarterRegex.FindStringSubmatch(text); m != nil {
+		year := today.Year()
+		if m[1] != "" {
+			year, _ = strconv.Atoi(m[1])
+		}
+		quarter, _ := strconv.Atoi(m[2])
+		start := Date(year, time.Month(3*(quarter-1)+1), 1)
+		return start, start.AddDate(0, 3, 0), nil
+	}
+
+	date, err := parseDay(text, today)
+	if err != nil {
+		return NoTime, NoTime, err
+	}
+	return date, AddDays(date, 1), nil
+}
+
+// parseDay parses a single day
+func parseDay(text string, today time.Time) (time.Time, error) {
 	switch text {
 	case "today":
-		return ToDate(time.Now()), nil
+		return today, nil
 	case "tomorrow":
-		return ToDate(time.Now()).Add(24 * time.Hour), nil
+		return AddDays(today, 1), nil
 	case "yesterday":
-		return ToDate(time.Now()).Add(-24 * time.Hour), nil
+		return AddDays(today, -1), nil
+	}
+	if wd, ok := weekdays[text]; ok {
+		return lastWeekday(today, wd, true), nil
+	}
+	if wd, ok := weekdays[strings.TrimPrefix(text, "last ")]; ok {
+		return lastWeekday(today, wd, false), nil
 	}
 	return time.ParseInLocation(DateFormat, text, time.Local)
 }
 
+// ParseWeekday parses the english name of a weekday
+func ParseWeekday(text string) (time.Weekday, error) {
+	if wd, ok := weekdays[strings.ToLower(strings.TrimSpace(text))]; ok {
+		return wd, nil
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday '%s'", text)
+}
+
+// lastWeekday returns the last date with the given weekday, before or at the given date
+func lastWeekday(date time.Time, weekday time.Weekday, includeDate bool) time.Time {
+	diff := (int(date.Weekday()) - int(weekday) + 7) % 7
+	if diff == 0 && !includeDate {
+		diff = 7
+	}
+	return AddDays(date, -diff)
+}
+
+// AddDays adds a number of calendar days to a date
+func AddDays(date time.Time, days int) time.Time {
+	return date.AddDate(0, 0, days)
+}
+
 // ParseDateTime parses a datetime string. Assumes the local time zone.
 func ParseDateTime(text string) (time.Time, error) {
 	return time.ParseInLocation(DateTimeFormat, text, time.Local)
@@ -76,6 +226,38 @@ func ParseTimeRange(text string, date time.Time) (start, end time.Time, err erro
 	return start, end, nil
 }
 
+// ParseDateTimeRange parses a time range, optionally preceded by a date.
+// Assumes today if no date is given. Dates are parsed like in ParseDate.
+//
+// Examples:
+//
+//	09:00 - 10:30
+//	yesterday 14:00-15:00
+//	last friday <23:00 - 1h30m
+//	2022-12-31 10:00 - 12:00
+func ParseDateTimeRange(text string, cal Calendar) (start, end time.Time, err error) {
+	return parseDateTimeRange(text, cal, ToDate(time.Now()))
+}
+
+// parseDateTimeRange parses a time range, optionally preceded by a date, relative to the given day
+func parseDateTimeRange(text string, cal Calendar, today time.Time) (start, end time.Time, err error) {
+	tokens := strings.Fields(text)
+	for i := 0; i < len(tokens); i++ {
+		date := today
+		if i > 0 {
+			date, err = parseDate(strings.Join(tokens[:i], " "), cal, today)
+			if err != nil {
+				continue
+			}
+		}
+		start, end, err = ParseTimeRange(strings.Join(tokens[i:], " "), date)
+		if err == nil {
+			return
+		}
+	}
+	return NoTime, NoTime, fmt.Errorf("invalid time range '%s'", text)
+}
+
 // ParseTimeWithOffset parses a time with offset markers
 func ParseTimeWithOffset(text string, date time.Time) (time.Time, error) {
 	dayOffset := 0
@@ -100,6 +282,33 @@ func ParseTimeWithOffset(text string, date time.Time) (time.Time, error) {
 	return t, nil
 }
 
+// ParseDuration parses a duration.
+// In addition to the formats understood by time.ParseDuration,
+// accepts a leading number of days or weeks, like "30d", "2w" or "1d12h"
+func ParseDuration(text string) (time.Duration, error) {
+	text = strings.TrimSpace(strings.ToLower(text))
+	match := longDurationRegex.FindStringSubmatch(text)
+	if match == nil {
+		return time.ParseDuration(text)
+	}
+	num, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	if match[2] == "w" {
+		num *= 7
+	}
+	dur := time.Duration(num) * 24 * time.Hour
+	if match[3] != "" {
+		rest, err := time.ParseDuration(match[3])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", text)
+		}
+		dur += rest
+	}
+	return dur, nil
+}
+
 // DateAndTime combines a date with a time
 func DateAndTime(d, t time.Time) time.Time {
 	return time.Date(
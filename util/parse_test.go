@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -133,13 +134,223 @@ func TestParseDate(t *testing.T) {
 			text:    "2022-12-31",
 			expDate: Date(2022, 12, 31),
 		},
+		{
+			title:   "weekday",
+			text:    strings.ToLower(today.Weekday().String()),
+			expDate: today,
+		},
+		{
+			title:   "last weekday",
+			text:    "last " + today.Weekday().String(),
+			expDate: today.AddDate(0, 0, -7),
+		},
+		{
+			title:   "last weekday yesterday",
+			text:    "last " + today.AddDate(0, 0, -1).Weekday().String(),
+			expDate: today.AddDate(0, 0, -1),
+		},
 	}
 
 	for _, test := range tt {
-		date, err := ParseDate(test.text)
+		date, err := ParseDate(test.text, DefaultCalendar())
 		assert.Nil(t, err, "Error parsing date in %s", test.title)
 		assert.Equal(t, test.expDate, date, "Wrong date in %s", test.title)
 	}
+
+	_, err := ParseDate("next friday", DefaultCalendar())
+	assert.NotNil(t, err, "Expected error parsing invalid date")
+
+	for _, text := range []string{"2023", "2023-03", "2023-W14", "this week", "q1"} {
+		_, err := ParseDate(text, DefaultCalendar())
+		assert.NotNil(t, err, "Expected error parsing range '%s' as a single date", text)
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	today := ToDate(time.Now())
+	monday := Monday(today)
+	month := Date(today.Year(), today.Month(), 1)
+
+	tt := []struct {
+		title    string
+		text     string
+		expStart time.Time
+		expEnd   time.Time
+	}{
+		{
+			title:    "single date",
+			text:     "2022-12-31",
+			expStart: Date(2022, 12, 31),
+			expEnd:   Date(2023, 1, 1),
+		},
+		{
+			title:    "relative days",
+			text:     "-3d",
+			expStart: today.AddDate(0, 0, -3),
+			expEnd:   today.AddDate(0, 0, -2),
+		},
+		{
+			title:    "relative weeks",
+			text:     "+1w",
+			expStart: today.AddDate(0, 0, 7),
+			expEnd:   today.AddDate(0, 0, 8),
+		},
+		{
+			title:    "this week",
+			text:     "this week",
+			expStart: monday,
+			expEnd:   monday.AddDate(0, 0, 7),
+		},
+		{
+			title:    "last week",
+			text:     "Last Week",
+			expStart: monday.AddDate(0, 0, -7),
+			expEnd:   monday,
+		},
+		{
+			title:    "last month",
+			text:     "last month",
+			expStart: month.AddDate(0, -1, 0),
+			expEnd:   month,
+		},
+		{
+			title:    "next year",
+			text:     "next year",
+			expStart: Date(today.Year()+1, 1, 1),
+			expEnd:   Date(today.Year()+2, 1, 1),
+		},
+		{
+			title:    "ISO week",
+			text:     "2026-W14",
+			expStart: Date(2026, 3, 30),
+			expEnd:   Date(2026, 4, 6),
+		},
+		{
+			title:    "ISO week 1",
+			text:     "2021-W01",
+			expStart: Date(2021, 1, 4),
+			expEnd:   Date(2021, 1, 11),
+		},
+		{
+			title:    "ISO week 53",
+			text:     "2020-W53",
+			expStart: Date(2020, 12, 28),
+			expEnd:   Date(2021, 1, 4),
+		},
+		{
+			title:    "month",
+			text:     "2026-03",
+			expStart: Date(2026, 3, 1),
+			expEnd:   Date(2026, 4, 1),
+		},
+		{
+			title:    "year",
+			text:     "2026",
+			expStart: Date(2026, 1, 1),
+			expEnd:   Date(2027, 1, 1),
+		},
+		{
+			title:    "quarter",
+			text:     "q1",
+			expStart: Date(today.Year(), 1, 1),
+			expEnd:   Date(today.Year(), 4, 1),
+		},
+		{
+			title:    "quarter with year",
+			text:     "2025-Q4",
+			expStart: Date(2025, 10, 1),
+			expEnd:   Date(2026, 1, 1),
+		},
+	}
+
+	for _, test := range tt {
+		start, end, err := ParseDateRange(test.text, DefaultCalendar())
+		assert.Nil(t, err, "Error parsing date range in %s", test.title)
+		assert.Equal(t, test.expStart, start, "Wrong start in %s", test.title)
+		assert.Equal(t, test.expEnd, end, "Wrong end in %s", test.title)
+	}
+
+	for _, text := range []string{"2026-W54", "2023-W53", "2026-W00", "2026-13", "q5", "last decade"} {
+		_, _, err := ParseDateRange(text, DefaultCalendar())
+		assert.NotNil(t, err, "Expected error parsing '%s'", text)
+	}
+}
+
+func TestParseDateTimeRange(t *testing.T) {
+	today := Date(2023, 3, 15)
+	yesterday := Date(2023, 3, 14)
+
+	tt := []struct {
+		title    string
+		text     string
+		expStart time.Time
+		expEnd   time.Time
+	}{
+		{
+			title:    "no date",
+			text:     "09:00 - 10:30",
+			expStart: today.Add(9 * time.Hour),
+			expEnd:   today.Add(10*time.Hour + 30*time.Minute),
+		},
+		{
+			title:    "yesterday",
+			text:     "yesterday 14:00-15:00",
+			expStart: yesterday.Add(14 * time.Hour),
+			expEnd:   yesterday.Add(15 * time.Hour),
+		},
+		{
+			title:    "last weekday",
+			text:     "last friday 10:00 - 1h30m",
+			expStart: DateTime(2023, 3, 10, 10, 0, 0),
+			expEnd:   DateTime(2023, 3, 10, 11, 30, 0),
+		},
+		{
+			title:    "date and duration",
+			text:     "2022-12-31 <23:00 - 1h30m",
+			expStart: DateTime(2022, 12, 30, 23, 0, 0),
+			expEnd:   DateTime(2022, 12, 31, 0, 30, 0),
+		},
+	}
+
+	for _, test := range tt {
+		start, end, err := parseDateTimeRange(test.text, DefaultCalendar(), today)
+		assert.Nil(t, err, "Error parsing time range in %s", test.title)
+		assert.Equal(t, test.expStart, start, "Wrong start in %s", test.title)
+		assert.Equal(t, test.expEnd, end, "Wrong end in %s", test.title)
+	}
+
+	_, _, err := parseDateTimeRange("yesterday", DefaultCalendar(), today)
+	assert.NotNil(t, err, "Expected error parsing range without times")
+	_, _, err = parseDateTimeRange("foo 10:00 - 11:00", DefaultCalendar(), today)
+	assert.NotNil(t, err, "Expected error parsing range with invalid date")
+	_, _, err = parseDateTimeRange("2023 10:00 - 11:00", DefaultCalendar(), today)
+	assert.NotNil(t, err, "Expected error parsing range with a year instead of a date")
+}
+
+func TestParseDuration(t *testing.T) {
+	tt := []struct {
+		title    string
+		text     string
+		expected time.Duration
+		hasError bool
+	}{
+		{"hours", "2h30m", 150 * time.Minute, false},
+		{"days", "30d", 30 * 24 * time.Hour, false},
+		{"weeks", "2w", 14 * 24 * time.Hour, false},
+		{"days and hours", "1d12h", 36 * time.Hour, false},
+		{"invalid", "1x", 0, true},
+		{"invalid rest", "1dfoo", 0, true},
+	}
+
+	for _, test := range tt {
+		dur, err := ParseDuration(test.text)
+		if test.hasError {
+			assert.NotNil(t, err, "Expected error in %s", test.title)
+			continue
+		}
+		assert.Nil(t, err, "Unexpected error in %s", test.title)
+		assert.Equal(t, test.expected, dur, "Wrong duration in %s", test.title)
+	}
 }
 
 func BenchmarkParseTimeRange(b *testing.B) {
@@ -1,6 +1,9 @@
 package util
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // NoTime is a zero time
 var NoTime time.Time = time.Time{}
@@ -35,3 +38,126 @@ func Monday(date time.Time) time.Time {
 	weekDay := (int(date.Weekday()) + 6) % 7
 	return date.Add(time.Duration(-weekDay * 24 * int(time.Hour)))
 }
+
+// Calendar holds the settings for week and period calculations
+type Calendar struct {
+	// First day of the week
+	FirstWeekday time.Weekday
+	// Custom reporting period, like fiscal months
+	Period Period
+}
+
+// DefaultCalendar returns a calendar with weeks starting on monday, and calendar months as custom periods
+func DefaultCalendar() Calendar {
+	return Calendar{
+		FirstWeekday: time.Monday,
+		Period:       Period{Months: 1, StartDay: 1, StartMonth: 1},
+	}
+}
+
+// WeekStart returns the first day of the week of the given date, according to FirstWeekday
+func (c Calendar) WeekStart(date time.Time) time.Time {
+	return lastWeekday(ToDate(date), c.FirstWeekday, true)
+}
+
+// Weekday returns the weekday in the given row of a week, starting at FirstWeekday
+func (c Calendar) Weekday(row int) time.Weekday {
+	return time.Weekday((int(c.FirstWeekday) + row) % 7)
+}
+
+// WeekdayIndex returns the position of a weekday in a week starting at FirstWeekday
+func (c Calendar) WeekdayIndex(wd time.Weekday) int {
+	return (int(wd) - int(c.FirstWeekday) + 7) % 7
+}
+
+// Period defines periods of one or more months, starting at a given day of the month.
+//
+// Example: fiscal months from the 26th to the 25th are defined by
+// Months = 1 and StartDay = 26.
+type Period struct {
+	// Length of a period in months, 1-12. Defaults to 1 if zero
+	Months int `yaml:"months"`
+	// Day of the month on which periods start, 1-28. Defaults to 1 if zero
+	StartDay int `yaml:"startDay"`
+	// Month of the year in which the first period of a year starts, 1-12. Defaults to 1 if zero.
+	// Only relevant for periods longer than one month
+	StartMonth int `yaml:"startMonth"`
+}
+
+// Check checks the period definition for consistency. Zero values are accepted and replaced by defaults
+func (p Period) Check() error {
+	if p.Months < 0 || p.Months > 12 {
+		return fmt.Errorf("period length must be between 1 and 12 months, or 0 for the default, got %d", p.Months)
+	}
+	if p.StartDay < 0 || p.StartDay > 28 {
+		return fmt.Errorf("period start day must be between 1 and 28, or 0 for the default, got %d", p.StartDay)
+	}
+	if p.StartMonth < 0 || p.StartMonth > 12 {
+		return fmt.Errorf("period start month must be between 1 and 12, or 0 for the default, got %d", p.StartMonth)
+	}
+	return nil
+}
+
+// Start returns the start date of the period containing the given date
+func (p Period) Start(date time.Time) time.Time {
+	months, startDay, startMonth := p.values()
+	year, month, day := date.Date()
+	if day < startDay {
+		month--
+	}
+	offset := ((int(month)-startMonth)%months + months) % months
+	return Date(year, month-time.Month(offset), startDay)
+}
+
+// Next returns the start date of the period following the period that starts at the given date
+func (p Period) Next(start time.Time) time.Time {
+	months, _, _ := p.values()
+	return start.AddDate(0, months, 0)
+}
+
+// values returns the period's values, with defaults for zero values
+func (p Period) values() (months, startDay, startMonth int) {
+	months, startDay, startMonth = p.Months, p.StartDay, p.StartMonth
+	if months <= 0 {
+		months = 1
+	}
+	if startDay <= 0 {
+		startDay = 1
+	}
+	if startMonth <= 0 {
+		startMonth = 1
+	}
+	return
+}
+
+// PeriodRange returns the start and the exclusive end of the period containing the given date,
+// shifted by offset periods. Supported units are week, month, quarter, year,
+// and period for the calendar's custom period.
+func (c Calendar) PeriodRange(unit string, date time.Time, offset int) (time.Time, time.Time, error) {
+	date = ToDate(date)
+	switch unit {
+	case "week":
+		start := AddDays(c.WeekStart(date), 7*offset)
+		return start, AddDays(start, 7), nil
+	case "period":
+		start := c.Period.Start(date)
+		for ; offset < 0; offset++ {
+			start = c.Period.Start(AddDays(start, -1))
+		}
+		for ; offset > 0; offset-- {
+			start = c.Period.Next(start)
+		}
+		return start, c.Period.Next(start), nil
+	case "month":
+		start := Date(date.Year(), date.Month()+time.Month(offset), 1)
+		return start, start.AddDate(0, 1, 0), nil
+	case "quarter":
+		quarter := (int(date.Month()) - 1) / 3
+		start := Date(date.Year(), time.Month(3*(quarter+offset)+1), 1)
+		return start, start.AddDate(0, 3, 0), nil
+	case "year":
+		start := Date(date.Year()+offset, 1, 1)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+	return NoTime, NoTime, fmt.Errorf("invalid period unit '%s'", unit)
+}
@@ -103,3 +103,124 @@ func TestMonday(t *testing.T) {
 		assert.Equal(t, time.Monday, monday.Weekday(), "Weekday should be monday")
 	}
 }
+
+func TestWeekStart(t *testing.T) {
+	cal := DefaultCalendar()
+
+	date := DateTime(2023, 1, 4, 12, 0, 0)
+	assert.Equal(t, Date(2023, 1, 2), cal.WeekStart(date), "Wrong week start")
+
+	cal.FirstWeekday = time.Sunday
+	assert.Equal(t, Date(2023, 1, 1), cal.WeekStart(date), "Wrong week start")
+	assert.Equal(t, Date(2023, 1, 1), cal.WeekStart(Date(2023, 1, 1)), "Wrong week start")
+
+	assert.Equal(t, time.Monday, cal.Weekday(1), "Wrong weekday")
+	assert.Equal(t, 0, cal.WeekdayIndex(time.Sunday), "Wrong weekday index")
+	assert.Equal(t, 6, cal.WeekdayIndex(time.Saturday), "Wrong weekday index")
+}
+
+func TestPeriod(t *testing.T) {
+	tt := []struct {
+		title    string
+		period   Period
+		date     time.Time
+		expStart time.Time
+		expNext  time.Time
+	}{
+		{
+			title:    "calendar month",
+			period:   Period{Months: 1, StartDay: 1, StartMonth: 1},
+			date:     DateTime(2023, 3, 15, 12, 0, 0),
+			expStart: Date(2023, 3, 1),
+			expNext:  Date(2023, 4, 1),
+		},
+		{
+			title:    "zero values",
+			period:   Period{},
+			date:     Date(2023, 3, 1),
+			expStart: Date(2023, 3, 1),
+			expNext:  Date(2023, 4, 1),
+		},
+		{
+			title:    "fiscal month, before start day",
+			period:   Period{Months: 1, StartDay: 26},
+			date:     Date(2023, 1, 25),
+			expStart: Date(2022, 12, 26),
+			expNext:  Date(2023, 1, 26),
+		},
+		{
+			title:    "fiscal month, at start day",
+			period:   Period{Months: 1, StartDay: 26},
+			date:     Date(2023, 1, 26),
+			expStart: Date(2023, 1, 26),
+			expNext:  Date(2023, 2, 26),
+		},
+		{
+			title:    "fiscal quarter",
+			period:   Period{Months: 3, StartDay: 26, StartMonth: 1},
+			date:     Date(2023, 1, 10),
+			expStart: Date(2022, 10, 26),
+			expNext:  Date(2023, 1, 26),
+		},
+		{
+			title:    "fiscal year",
+			period:   Period{Months: 12, StartDay: 1, StartMonth: 7},
+			date:     Date(2023, 3, 10),
+			expStart: Date(2022, 7, 1),
+			expNext:  Date(2023, 7, 1),
+		},
+	}
+
+	for _, test := range tt {
+		start := test.period.Start(test.date)
+		assert.Equal(t, test.expStart, start, "Wrong period start in %s", test.title)
+		assert.Equal(t, test.expNext, test.period.Next(start), "Wrong next period in %s", test.title)
+	}
+
+	assert.NotNil(t, Period{Months: 1, StartDay: 29}.Check(), "Expected error for invalid start day")
+	assert.NotNil(t, Period{Months: 13}.Check(), "Expected error for invalid length")
+	assert.NotNil(t, Period{StartMonth: -1}.Check(), "Expected error for invalid start month")
+	assert.Nil(t, Period{Months: 1, StartDay: 26}.Check(), "Expected no error for valid period")
+	assert.Nil(t, Period{}.Check(), "Expected no error for default period")
+}
+
+func TestPeriodRange(t *testing.T) {
+	cal := DefaultCalendar()
+
+	tt := []struct {
+		title    string
+		unit     string
+		offset   int
+		expStart time.Time
+		expEnd   time.Time
+	}{
+		{"week", "week", 0, Date(2023, 3, 13), Date(2023, 3, 20)},
+		{"previous week", "week", -1, Date(2023, 3, 6), Date(2023, 3, 13)},
+		{"month", "month", 0, Date(2023, 3, 1), Date(2023, 4, 1)},
+		{"previous month", "month", -3, Date(2022, 12, 1), Date(2023, 1, 1)},
+		{"quarter", "quarter", 1, Date(2023, 4, 1), Date(2023, 7, 1)},
+		{"year", "year", -1, Date(2022, 1, 1), Date(2023, 1, 1)},
+		{"period", "period", -1, Date(2023, 2, 1), Date(2023, 3, 1)},
+	}
+
+	date := DateTime(2023, 3, 15, 12, 0, 0)
+	for _, test := range tt {
+		start, end, err := cal.PeriodRange(test.unit, date, test.offset)
+		assert.Nil(t, err, "Unexpected error in %s", test.title)
+		assert.Equal(t, test.expStart, start, "Wrong start in %s", test.title)
+		assert.Equal(t, test.expEnd, end, "Wrong end in %s", test.title)
+	}
+
+	_, _, err := cal.PeriodRange("foo", date, 0)
+	assert.NotNil(t, err, "Expected error for invalid unit")
+
+	cal = Calendar{FirstWeekday: time.Sunday, Period: Period{Months: 1, StartDay: 26}}
+	start, end, err := cal.PeriodRange("week", date, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, Date(2023, 3, 12), start, "Wrong start of week")
+	assert.Equal(t, Date(2023, 3, 19), end, "Wrong end of week")
+	start, end, err = cal.PeriodRange("period", date, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, Date(2023, 2, 26), start, "Wrong start of period")
+	assert.Equal(t, Date(2023, 3, 26), end, "Wrong end of period")
+}